@@ -2,24 +2,38 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bcmister/cc/internal/config"
 	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/registry"
 	"github.com/bcmister/cc/internal/ui"
 	"github.com/bcmister/cc/internal/window"
 	"github.com/spf13/cobra"
 )
 
+var (
+	allDryRun bool
+	allOutput string
+)
+
 var allCmd = &cobra.Command{
 	Use:   "all",
 	Short: "Launch terminal windows across all monitors with per-window CLI selection",
 	RunE:  runAll,
 }
 
+func init() {
+	allCmd.Flags().BoolVar(&allDryRun, "dry-run", false, "print the launch plan as JSON instead of launching")
+	allCmd.Flags().StringVarP(&allOutput, "output", "o", "", "with --dry-run, write the plan to this file")
+}
+
 func runAll(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -35,27 +49,65 @@ func runAll(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	l, err := window.NewLauncher(cfg)
+	if err != nil {
+		return err
+	}
+
 	// Detect monitors
-	monitors, err := monitor.Detect()
+	monitors, err := detectMonitors(cfg, l)
 	if err != nil {
 		return fmt.Errorf("failed to detect monitors: %w", err)
 	}
 
+	// A plan printed to stdout must be all that goes there
+	if allDryRun && allOutput == "" {
+		defer func(out io.Writer) { ui.Out = out }(ui.Out)
+		ui.Out = os.Stderr
+	}
+
 	ui.Logo("")
 	ui.Sep()
 
 	ui.Head(fmt.Sprintf("Detected %d monitors", len(monitors)))
-	fmt.Println()
+	fmt.Fprintln(ui.Out)
 	for i, m := range monitors {
 		badge := ""
 		if m.Primary {
 			badge = "Primary"
 		}
 		ui.BoxStart(fmt.Sprintf("Monitor %d", i+1), badge)
-		ui.BoxRow(fmt.Sprintf("%s%d × %d%s", ui.BrWhite, m.Width, m.Height, ui.Reset))
+		if m.Width == 0 {
+			ui.BoxRow(fmt.Sprintf("%s%s window%s", ui.BrWhite, l.Name(), ui.Reset))
+		} else {
+			ui.BoxRow(fmt.Sprintf("%s%d × %d%s", ui.BrWhite, m.Width, m.Height, ui.Reset))
+		}
 		ui.BoxEnd()
 	}
 
+	answers, err := loadAnswers(answersPath)
+	if err != nil {
+		return err
+	}
+	flags, err := parseAllFlags()
+	if err != nil {
+		return err
+	}
+	answers.override(flags)
+	if cmd.Flags().Changed("loop") {
+		// Also set through the bare command when run as "all"
+		cfg.Loop, _ = cmd.Flags().GetBool("loop")
+	}
+	if err := answers.validate(len(monitors)); err != nil {
+		return err
+	}
+	if answers.projects != "" {
+		if err := answers.checkRoot(); err != nil {
+			return err
+		}
+		cfg.ProjectsRoot = answers.projects
+	}
+
 	// Grow or shrink monitor configs to match detected monitors
 	for len(cfg.Monitors) < len(monitors) {
 		cfg.Monitors = append(cfg.Monitors, config.MonitorConfig{
@@ -72,13 +124,17 @@ func runAll(cmd *cobra.Command, args []string) error {
 			defaultCount = 1
 		}
 
-		ui.Prompt(fmt.Sprintf("Windows on Monitor %d", i+1), strconv.Itoa(defaultCount))
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
 		count := defaultCount
-		if input != "" {
-			if n, err := strconv.Atoi(input); err == nil && n >= 1 {
-				count = n
+		if n := answers.windowsFor(i); n > 0 {
+			count = n
+		} else if !allYes {
+			ui.Prompt(fmt.Sprintf("Windows on Monitor %d", i+1), strconv.Itoa(defaultCount))
+			input, _ := reader.ReadString('\n')
+			input = strings.TrimSpace(input)
+			if input != "" {
+				if n, err := strconv.Atoi(input); err == nil && n >= 1 {
+					count = n
+				}
 			}
 		}
 
@@ -94,11 +150,14 @@ func runAll(cmd *cobra.Command, args []string) error {
 		}
 		cfg.Monitors[i].Windows = windows
 
-		// Auto-set layout
-		switch count {
-		case 1:
+		// Auto-set layout unless one was given, or --yes keeps the saved one
+		switch {
+		case answers.layoutFor(i) != "":
+			cfg.Monitors[i].Layout = answers.layoutFor(i)
+		case allYes && count == len(existing) && cfg.Monitors[i].Layout != "":
+		case count == 1:
 			cfg.Monitors[i].Layout = "full"
-		case 2:
+		case count == 2:
 			cfg.Monitors[i].Layout = "vertical"
 		default:
 			cfg.Monitors[i].Layout = "grid"
@@ -106,10 +165,18 @@ func runAll(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 2: For each window, prompt tool selection (cc or cx)
-	fmt.Println()
+	fmt.Fprintln(ui.Out)
 	for i := range monitors {
 		for j := range cfg.Monitors[i].Windows {
 			defaultTool := cfg.Monitors[i].ToolFor(j)
+			if tool := answers.toolFor(i, j); tool != "" {
+				cfg.Monitors[i].Windows[j].Tool = tool
+				continue
+			}
+			if allYes {
+				cfg.Monitors[i].Windows[j].Tool = defaultTool
+				continue
+			}
 			ui.Inline(fmt.Sprintf("Monitor %d, Window %d", i+1, j+1), defaultTool)
 			input, _ := reader.ReadString('\n')
 			input = strings.TrimSpace(strings.ToLower(input))
@@ -128,28 +195,71 @@ func runAll(cmd *cobra.Command, args []string) error {
 			tools[wc.Tool] = true
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(ui.Out)
 	for tool := range tools {
 		if err := config.ValidateCommand(tool); err != nil {
 			ui.Warn(err.Error())
 		}
 	}
 
+	if allDryRun {
+		plan, err := planLaunch(l, cfg, monitors)
+		if err != nil {
+			return err
+		}
+		return writePlan(plan, allOutput)
+	}
+
 	// Save config
-	if err := config.Save(cfg, ""); err != nil {
-		ui.Warn(fmt.Sprintf("Could not save config: %v", err))
+	if saveAnswers(cmd) {
+		if err := config.Save(cfg, ""); err != nil {
+			ui.Warn(fmt.Sprintf("Could not save config: %v", err))
+		}
 	}
 
 	// Launch
-	return launchAllV3(cfg, monitors)
+	return launchAllV3(cmd.Context(), l, cfg, monitors)
+}
+
+// detectMonitors returns the connected monitors. Launchers that do not place
+// windows on screen, such as tmux, only need one entry per configured
+// monitor, so where detection is unavailable they get placeholders instead.
+func detectMonitors(cfg *config.Config, l window.Launcher) ([]monitor.Monitor, error) {
+	monitors, err := monitor.Detect()
+	if err == nil {
+		return monitors, nil
+	}
+	if _, ok := l.(window.GroupLauncher); !ok {
+		return nil, err
+	}
+	return placeholderMonitors(cfg), nil
+}
+
+// placeholderMonitors returns one zero-sized monitor per configured monitor,
+// for targets that only need to know how windows are grouped
+func placeholderMonitors(cfg *config.Config) []monitor.Monitor {
+	count := len(cfg.Monitors)
+	if count < 1 {
+		count = 1
+	}
+	monitors := make([]monitor.Monitor, count)
+	for i := range monitors {
+		monitors[i] = monitor.Monitor{Name: fmt.Sprintf("Monitor %d", i+1), Primary: i == 0}
+	}
+	return monitors
 }
 
-func launchAllV3(cfg *config.Config, monitors []monitor.Monitor) error {
-	type monGroup struct {
-		monIdx  int
-		configs []window.LaunchConfig
+// buildLaunchGroups lays out every configured window against the detected
+// monitors. Monitor configs beyond the detected monitors are skipped. Titles
+// come from the config's title template and end in a run tag so each run's
+// windows can be told apart.
+func buildLaunchGroups(cfg *config.Config, monitors []monitor.Monitor, runID string) ([]window.Group, []window.LaunchConfig, error) {
+	tmpl, err := window.ParseTitleTemplate(cfg.TitleTemplate)
+	if err != nil {
+		return nil, nil, err
 	}
-	var groups []monGroup
+
+	var groups []window.Group
 	var allConfigs []window.LaunchConfig
 
 	for i, mc := range cfg.Monitors {
@@ -157,38 +267,131 @@ func launchAllV3(cfg *config.Config, monitors []monitor.Monitor) error {
 			break
 		}
 		positions := window.CalculateLayout(&monitors[i], mc.WindowCount(), mc.Layout)
-		g := monGroup{monIdx: i}
+		g := window.Group{Monitor: i, Layout: mc.Layout}
 		for j, pos := range positions {
 			tool := mc.ToolFor(j)
+			fields := window.TitleFields{
+				Monitor:      mc.DisplayName(i),
+				MonitorIndex: i + 1,
+				Window:       j + 1,
+				Tool:         tool,
+				RunID:        runID,
+			}
+			title, err := tmpl.Render(fields)
+			if err != nil {
+				return nil, nil, err
+			}
+			picked, err := tmpl.Render(pickedFields(fields, cfg.Profiles))
+			if err != nil {
+				return nil, nil, err
+			}
+			tag := window.RunTag(runID, i, j)
 			lc := window.LaunchConfig{
-				Title:      fmt.Sprintf("%s-%d-%d", tool, i+1, j+1),
-				WorkingDir: cfg.ProjectsRoot,
-				X:          pos.X,
-				Y:          pos.Y,
-				Width:      pos.Width,
-				Height:     pos.Height,
-				Command:    config.CommandFor(tool),
-				Label:      config.LabelFor(tool),
-				Profiles:   cfg.Profiles,
+				Title:       title + " " + tag,
+				PickedTitle: picked + " " + tag,
+				WorkingDir:  cfg.ProjectsRoot,
+				X:           pos.X,
+				Y:           pos.Y,
+				Width:       pos.Width,
+				Height:      pos.Height,
+				Command:     config.CommandFor(tool),
+				Label:       config.LabelFor(tool),
+				Profiles:    cfg.Profiles,
+				Loop:        cfg.Loop,
 			}
 			allConfigs = append(allConfigs, lc)
-			g.configs = append(g.configs, lc)
+			g.Configs = append(g.Configs, lc)
 		}
 		groups = append(groups, g)
 	}
 
-	ui.Sep()
+	return groups, allConfigs, nil
+}
 
-	// Use current terminal for first window, spawn others
-	launchResult := window.LaunchAllWithCurrent(allConfigs)
-	results := launchResult.Results
+// pickedFields fills in the placeholders the picker replaces once a project
+// and account are chosen
+func pickedFields(f window.TitleFields, profiles []config.Profile) window.TitleFields {
+	f.Project = window.ProjectPlaceholder
+	if len(profiles) > 0 {
+		f.Profile = window.ProfilePlaceholder
+	}
+	return f
+}
+
+func launchAllV3(ctx context.Context, l window.Launcher, cfg *config.Config, monitors []monitor.Monitor) error {
+	plan, err := planLaunch(l, cfg, monitors)
+	if err != nil {
+		return err
+	}
+	return applyPlan(ctx, l, plan)
+}
+
+// planLaunch works out what launching cfg on monitors through l will do,
+// without opening anything
+func planLaunch(l window.Launcher, cfg *config.Config, monitors []monitor.Monitor) (*window.Plan, error) {
+	runID := window.NewRunID()
+	groups, _, err := buildLaunchGroups(cfg, monitors, runID)
+	if err != nil {
+		return nil, err
+	}
+	return window.NewPlan(l, runID, monitors, groups), nil
+}
+
+// applyPlan opens the windows of plan through l, then runs the picker in
+// the current terminal
+func applyPlan(ctx context.Context, l window.Launcher, plan *window.Plan) error {
+	// Record the run first so watch can find the windows as soon as they appear
+	if err := registry.Save(newRun(plan.RunID, plan.Groups), ""); err != nil {
+		ui.Warn(fmt.Sprintf("Could not record launched windows: %v", err))
+	}
+
+	launchResult := launchWith(ctx, l, plan.Monitors, plan.Groups)
 
-	newWindows := len(allConfigs) - 1
-	if newWindows > 0 {
-		ui.Head(fmt.Sprintf("Launching %d new terminals (using current for first)", newWindows))
+	// Run picker in current terminal (blocking)
+	return launchResult.RunPicker()
+}
+
+// newRun describes the launched groups for the run registry
+func newRun(runID string, groups []window.Group) *registry.Run {
+	run := &registry.Run{ID: runID, Started: time.Now()}
+	for _, g := range groups {
+		for j, c := range g.Configs {
+			run.Windows = append(run.Windows, registry.Window{Title: c.Title, Monitor: g.Monitor, Index: j})
+		}
+	}
+	return run
+}
+
+// launchWith opens every grouped window through l and prints the
+// per-monitor status panels. The picker for the current terminal is
+// returned rather than run.
+func launchWith(ctx context.Context, l window.Launcher, monitors []monitor.Monitor, groups []window.Group) window.LaunchAllWithCurrentResult {
+	var allConfigs []window.LaunchConfig
+	for _, g := range groups {
+		allConfigs = append(allConfigs, g.Configs...)
+	}
+	if len(allConfigs) > 0 {
+		allConfigs[0].CdFile = cdFile
+	}
+
+	ui.Sep()
+
+	var launchResult window.LaunchAllWithCurrentResult
+	if gl, ok := l.(window.GroupLauncher); ok {
+		// One window per monitor with a pane per config
+		launchResult = window.LaunchGroups(gl, groups)
+		ui.Head(fmt.Sprintf("Launching %d terminals in %s", len(allConfigs), l.Name()))
 	} else {
-		ui.Head("Using current terminal")
+		// Use current terminal for first window, spawn others
+		launchResult = window.LaunchAllWithCurrent(ctx, l, allConfigs)
+		newWindows := len(allConfigs) - 1
+		if newWindows > 0 {
+			ui.Head(fmt.Sprintf("Launching %d new terminals (using current for first)", newWindows))
+		} else {
+			ui.Head("Using current terminal")
+		}
 	}
+	results := launchResult.Results
 	fmt.Println()
 
 	// Build result lookup
@@ -200,11 +403,11 @@ func launchAllV3(cfg *config.Config, monitors []monitor.Monitor) error {
 	// Display per-monitor panels
 	for _, g := range groups {
 		badge := ""
-		if monitors[g.monIdx].Primary {
+		if monitors[g.Monitor].Primary {
 			badge = "Primary"
 		}
-		ui.BoxStart(fmt.Sprintf("Monitor %d", g.monIdx+1), badge)
-		for _, c := range g.configs {
+		ui.BoxStart(fmt.Sprintf("Monitor %d", g.Monitor+1), badge)
+		for _, c := range g.Configs {
 			err := resultMap[c.Title]
 			label := fmt.Sprintf("%s%s%s", ui.White, c.Title, ui.Reset)
 			ui.BoxRow(fmt.Sprintf("%s  %s%s%s",
@@ -229,8 +432,7 @@ func launchAllV3(cfg *config.Config, monitors []monitor.Monitor) error {
 	ui.Fin("Ready")
 	fmt.Println()
 
-	// Run picker in current terminal (blocking)
-	return launchResult.RunPicker()
+	return launchResult
 }
 
 func statusIcon(ok bool) string {
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+var (
+	allProjects string
+	allWindows  string
+	allLayouts  string
+	allTools    string
+	allYes      bool
+	allSave     bool
+	allLoop     bool
+)
+
+func init() {
+	allCmd.Flags().StringVar(&allProjects, "projects", "", "projects root to pick from")
+	allCmd.Flags().StringVar(&allWindows, "windows", "", "windows per monitor, e.g. 1,2,4")
+	allCmd.Flags().StringVar(&allLayouts, "layout", "", "layout per monitor, e.g. full,vertical,grid")
+	allCmd.Flags().StringVar(&allTools, "tools", "", "tool per window, monitors split by ',' and windows by '+', e.g. cc,cc+cx")
+	allCmd.Flags().BoolVarP(&allYes, "yes", "y", false, "skip prompts, using flags and the saved config")
+	allCmd.Flags().BoolVar(&allSave, "save", false, "also save answers given by flags or --answers to the config (--save=false saves nothing)")
+	allCmd.Flags().StringVar(&answersPath, "answers", "", "YAML file answering the prompts; flags override it")
+	allCmd.Flags().BoolVar(&allLoop, "loop", false, "windows go back to the picker when the tool exits (kept with --save)")
+}
+
+// answerFlags are the all flags that answer the wizard
+var answerFlags = []string{"projects", "windows", "layout", "tools", "loop", "answers"}
+
+// saveAnswers reports whether all saves its answers to the config. Answers
+// typed at the prompts are kept unless --save=false; those from flags or an
+// answers file only with --save.
+func saveAnswers(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("save") {
+		return allSave
+	}
+	for _, name := range answerFlags {
+		if cmd.Flags().Changed(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// launchAnswers are answers to the set and all wizards given before they
+// run, from flags or an answers file, indexed by monitor. Zero values mean
+// the answer was not given.
+type launchAnswers struct {
+	projects   string
+	createRoot *bool // create a missing projects root
+	monitors   int   // monitors listed in the answers file
+	windows    []int
+	layouts    []string
+	tools      [][]string
+
+	file     string          // answers file the answers in fromFile came from
+	fromFile map[string]bool // answers from the file, by key such as "windows[1]"
+}
+
+// key names where the monitor i answer to field ("windows", "layout" or
+// "tools") came from: its flag, or its key in the answers file
+func (a *launchAnswers) key(field string, i int) string {
+	if a.fromFile[fmt.Sprintf("%s[%d]", field, i)] {
+		return fmt.Sprintf("%s: monitors[%d].%s", a.file, i, field)
+	}
+	return "--" + field
+}
+
+// rootKey names where the projects root came from: --projects, or its key
+// in the answers file
+func (a *launchAnswers) rootKey() string {
+	if a.fromFile["projectsRoot"] {
+		return a.file + ": projectsRoot"
+	}
+	return "--projects"
+}
+
+// checkRoot makes sure the projects root answer is a directory, creating it
+// when the answers say to
+func (a *launchAnswers) checkRoot() error {
+	if _, err := os.Stat(a.projects); os.IsNotExist(err) && a.createRoot != nil && *a.createRoot {
+		if err := os.MkdirAll(a.projects, 0755); err != nil {
+			return fmt.Errorf("failed to create projects root: %w", err)
+		}
+	}
+	if info, err := os.Stat(a.projects); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s: %s is not a directory", a.rootKey(), a.projects)
+	}
+	return nil
+}
+
+// flagged records that the monitor i answer to field came from a flag
+func (a *launchAnswers) flagged(field string, i int) {
+	delete(a.fromFile, fmt.Sprintf("%s[%d]", field, i))
+}
+
+// parseAllFlags reads the all command's flags
+func parseAllFlags() (*launchAnswers, error) {
+	a := &launchAnswers{projects: config.ExpandPath(allProjects)}
+	var err error
+	if a.windows, err = parseCounts("--windows", allWindows); err != nil {
+		return nil, err
+	}
+	if a.layouts, err = parseLayouts("--layout", allLayouts); err != nil {
+		return nil, err
+	}
+	if a.tools, err = parseTools("--tools", allTools); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// override replaces answers in a with those given in b
+func (a *launchAnswers) override(b *launchAnswers) {
+	if b.projects != "" {
+		a.projects = b.projects
+		delete(a.fromFile, "projectsRoot")
+	}
+	if b.createRoot != nil {
+		a.createRoot = b.createRoot
+	}
+	for i, l := range b.layouts {
+		if l != "" {
+			setAt(&a.layouts, i, l)
+			a.flagged("layout", i)
+		}
+	}
+	for i := 0; i < max(len(b.windows), len(b.tools)); i++ {
+		n := b.windowsFor(i)
+		switch {
+		case i < len(b.tools) && len(b.tools[i]) > 0:
+			// Tools win together with whatever count came with them;
+			// a zero count is implied by the tools in validate
+			setAt(&a.tools, i, b.tools[i])
+			setAt(&a.windows, i, n)
+			a.flagged("tools", i)
+			a.flagged("windows", i)
+		case n > 0:
+			setAt(&a.windows, i, n)
+			a.flagged("windows", i)
+			// Tools from a no longer fit the overridden count
+			if i < len(a.tools) && len(a.tools[i]) != n {
+				a.tools[i] = nil
+				a.flagged("tools", i)
+			}
+		}
+	}
+}
+
+// setAt sets (*s)[i] = v, growing *s with zero values as needed
+func setAt[T any](s *[]T, i int, v T) {
+	for len(*s) <= i {
+		var zero T
+		*s = append(*s, zero)
+	}
+	(*s)[i] = v
+}
+
+// validate checks the answers against each other and the detected monitors,
+// filling in window counts implied by the tools
+func (a *launchAnswers) validate(monitorCount int) error {
+	if a.monitors > monitorCount {
+		return fmt.Errorf("monitors: %d given in %s but %d detected", a.monitors, answersPath, monitorCount)
+	}
+
+	for _, f := range []struct {
+		name string
+		n    int
+	}{{"windows", len(a.windows)}, {"layout", len(a.layouts)}, {"tools", len(a.tools)}} {
+		if f.n > monitorCount {
+			return fmt.Errorf("%s: %d monitors given but %d detected", a.key(f.name, f.n-1), f.n, monitorCount)
+		}
+	}
+
+	for i, tools := range a.tools {
+		if len(tools) == 0 {
+			continue
+		}
+		for len(a.windows) <= i {
+			a.windows = append(a.windows, 0)
+		}
+		switch a.windows[i] {
+		case 0:
+			a.windows[i] = len(tools)
+		case len(tools):
+		default:
+			return fmt.Errorf("%s: monitor %d has %d tools but %s asks for %d windows",
+				a.key("tools", i), i+1, len(tools), a.key("windows", i), a.windows[i])
+		}
+	}
+	return nil
+}
+
+func (a *launchAnswers) windowsFor(i int) int {
+	if i < len(a.windows) {
+		return a.windows[i]
+	}
+	return 0
+}
+
+func (a *launchAnswers) layoutFor(i int) string {
+	if i < len(a.layouts) {
+		return a.layouts[i]
+	}
+	return ""
+}
+
+func (a *launchAnswers) toolFor(i, j int) string {
+	if i < len(a.tools) && j < len(a.tools[i]) {
+		return a.tools[i][j]
+	}
+	return ""
+}
+
+// splitList splits a comma-separated flag value, keeping empty entries so
+// "1,,4" leaves monitor 2 to the config or a prompt
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func parseCounts(name, s string) ([]int, error) {
+	var counts []int
+	for i, p := range splitList(s) {
+		if p == "" {
+			counts = append(counts, 0)
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%s: monitor %d: %q is not a window count", name, i+1, p)
+		}
+		counts = append(counts, n)
+	}
+	return counts, nil
+}
+
+func parseLayouts(name, s string) ([]string, error) {
+	layouts := splitList(s)
+	for i, l := range layouts {
+		l = strings.ToLower(l)
+		if l != "" && !window.IsLayout(l) {
+			return nil, fmt.Errorf("%s: monitor %d: unknown layout %q (available: %s)", name, i+1, l, strings.Join(window.Layouts, ", "))
+		}
+		layouts[i] = l
+	}
+	return layouts, nil
+}
+
+func parseTools(name, s string) ([][]string, error) {
+	var tools [][]string
+	for i, p := range splitList(s) {
+		if p == "" {
+			tools = append(tools, nil)
+			continue
+		}
+		var monitorTools []string
+		for _, t := range strings.Split(p, "+") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "cc" && t != "cx" {
+				return nil, fmt.Errorf("%s: monitor %d: unknown tool %q (use cc or cx)", name, i+1, t)
+			}
+			monitorTools = append(monitorTools, t)
+		}
+		tools = append(tools, monitorTools)
+	}
+	return tools, nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseCounts(t *testing.T) {
+	got, err := parseCounts("--windows", "1, 2,,4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 0, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"0", "two", "1,-1"} {
+		if _, err := parseCounts("--windows", bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestParseLayouts(t *testing.T) {
+	got, err := parseLayouts("--layout", "Full,,grid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"full", "", "grid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, err := parseLayouts("--layout", "full,tiled"); err == nil || !strings.Contains(err.Error(), "monitor 2") {
+		t.Errorf("expected error naming monitor 2, got %v", err)
+	}
+}
+
+func TestParseTools(t *testing.T) {
+	got, err := parseTools("--tools", "cc,cc+CX,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"cc"}, {"cc", "cx"}, nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, err := parseTools("--tools", "cc+vim"); err == nil {
+		t.Errorf("expected error for unknown tool")
+	}
+}
+
+func TestLaunchAnswersValidate(t *testing.T) {
+	a := &launchAnswers{windows: []int{1}, tools: [][]string{nil, {"cc", "cx", "cc"}}}
+	if err := a.validate(2); err != nil {
+		t.Fatal(err)
+	}
+	if a.windowsFor(1) != 3 {
+		t.Errorf("expected --tools to imply 3 windows on monitor 2, got %d", a.windowsFor(1))
+	}
+	if a.toolFor(1, 1) != "cx" || a.toolFor(0, 0) != "" || a.toolFor(5, 0) != "" {
+		t.Errorf("unexpected tool lookups")
+	}
+
+	a = &launchAnswers{windows: []int{2}, tools: [][]string{{"cc"}}}
+	if err := a.validate(1); err == nil {
+		t.Errorf("expected error when --tools and --windows disagree")
+	}
+
+	a = &launchAnswers{layouts: []string{"full", "grid", "full"}}
+	if err := a.validate(2); err == nil || !strings.Contains(err.Error(), "--layout") {
+		t.Errorf("expected error naming --layout for too many monitors, got %v", err)
+	}
+
+	a = &launchAnswers{layouts: []string{"full", "grid", "full"}, file: "a.yaml", fromFile: map[string]bool{"layout[2]": true}}
+	if err := a.validate(2); err == nil || !strings.Contains(err.Error(), "a.yaml: monitors[2].layout") {
+		t.Errorf("expected error naming the answers file key, got %v", err)
+	}
+}
+
+func TestSaveAnswers(t *testing.T) {
+	t.Cleanup(func() { allSave = false })
+	for _, tt := range []struct {
+		set  map[string]string
+		want bool
+	}{
+		{nil, true},
+		{map[string]string{"save": "false"}, false},
+		{map[string]string{"windows": "1,2"}, false},
+		{map[string]string{"answers": "a.yaml"}, false},
+		{map[string]string{"windows": "1,2", "save": "true"}, true},
+	} {
+		cmd := &cobra.Command{}
+		cmd.Flags().BoolVar(&allSave, "save", false, "")
+		for _, name := range answerFlags {
+			cmd.Flags().String(name, "", "")
+		}
+		for k, v := range tt.set {
+			if err := cmd.Flags().Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := saveAnswers(cmd); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.set, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/window"
+)
+
+func testMonitors() []monitor.Monitor {
+	return []monitor.Monitor{
+		{Name: "Monitor 1", X: 0, Y: 0, Width: 1536, Height: 960, Primary: true},
+		{Name: "Monitor 2", X: 1536, Y: 0, Width: 1920, Height: 1080},
+	}
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Version:      4,
+		ProjectsRoot: "/projects",
+		Monitors: []config.MonitorConfig{
+			{Layout: "full", Windows: []config.WindowConfig{{Tool: "cc"}}},
+			{Layout: "vertical", Windows: []config.WindowConfig{{Tool: "cc"}, {Tool: "cx"}}},
+		},
+	}
+}
+
+func launchTest(l window.Launcher) window.LaunchAllWithCurrentResult {
+	groups, _, err := buildLaunchGroups(testConfig(), testMonitors(), "ab12cd")
+	if err != nil {
+		panic(err)
+	}
+	return launchWith(context.Background(), l, testMonitors(), groups)
+}
+
+func TestLaunchWithSpawnsAllButFirst(t *testing.T) {
+	rec := &window.RecordingLauncher{}
+	res := launchTest(rec)
+
+	if len(res.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(res.Results))
+	}
+
+	spawned := rec.CallsTo("NewWindow")
+	if len(spawned) != 2 {
+		t.Fatalf("expected 2 spawned windows, got %d", len(spawned))
+	}
+	if spawned[0].Spec.Title != "cc-2-1 #ab12cd.2.1" || spawned[1].Spec.Title != "cx-2-2 #ab12cd.2.2" {
+		t.Errorf("unexpected spawned titles %q, %q", spawned[0].Spec.Title, spawned[1].Spec.Title)
+	}
+	for _, c := range spawned {
+		if c.Spec.Dir != "/projects" {
+			t.Errorf("%s: expected dir /projects, got %s", c.Spec.Title, c.Spec.Dir)
+		}
+		if len(c.Spec.Argv) == 0 {
+			t.Errorf("%s: expected a picker command line", c.Spec.Title)
+		}
+	}
+
+	// Second window on monitor 2 sits in the right half
+	if got := spawned[1].Spec.Position; got != (window.Position{X: 1536 + 960, Y: 0, Width: 960, Height: 1080}) {
+		t.Errorf("cx-2-2: unexpected position %+v", got)
+	}
+
+	current := rec.CallsTo("PlaceCurrent")
+	if len(current) != 1 || current[0].Spec.Title != "cc-1-1 #ab12cd.1.1" {
+		t.Fatalf("expected current terminal placed as cc-1-1, got %+v", current)
+	}
+	if current[0].Spec.Width != 1536 || current[0].Spec.Height != 960 {
+		t.Errorf("expected current terminal to fill monitor 1, got %+v", current[0].Spec.Position)
+	}
+
+	if placed := rec.CallsTo("Place"); len(placed) != 2 {
+		t.Errorf("expected 2 placed windows, got %d", len(placed))
+	}
+}
+
+func TestLaunchWithReportsErrors(t *testing.T) {
+	rec := &window.RecordingLauncher{
+		Errors: map[string]error{"cx-2-2 #ab12cd.2.2": errors.New("boom")},
+	}
+	res := launchTest(rec)
+
+	for _, r := range res.Results {
+		if r.Title == "cx-2-2 #ab12cd.2.2" && r.Err == nil {
+			t.Errorf("expected error for cx-2-2")
+		}
+		if r.Title != "cx-2-2 #ab12cd.2.2" && r.Err != nil {
+			t.Errorf("%s: unexpected error %v", r.Title, r.Err)
+		}
+	}
+
+	// A window that failed to spawn is never placed
+	for _, c := range rec.CallsTo("Place") {
+		if c.Spec.Title == "cx-2-2 #ab12cd.2.2" {
+			t.Errorf("failed window should not be placed")
+		}
+	}
+}
+
+func TestBuildLaunchGroupsSkipsMissingMonitors(t *testing.T) {
+	groups, configs, err := buildLaunchGroups(testConfig(), testMonitors()[:1], "ab12cd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || len(configs) != 1 {
+		t.Errorf("expected only monitor 1 to be laid out, got %d groups, %d configs", len(groups), len(configs))
+	}
+}
+
+func TestBuildLaunchGroupsRendersTitleTemplate(t *testing.T) {
+	cfg := testConfig()
+	cfg.TitleTemplate = "{{.Monitor}}/{{.Window}} {{.Tool}}{{with .Project}} {{.}}{{end}}{{with .Profile}} ({{.}}){{end}}"
+	cfg.Monitors[1].Nickname = "right"
+	cfg.Profiles = []config.Profile{{Name: "work"}, {Name: "home"}}
+
+	_, configs, err := buildLaunchGroups(cfg, testMonitors(), "ab12cd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := configs[2].Title; got != "right/2 cx #ab12cd.2.2" {
+		t.Errorf("unexpected title %q", got)
+	}
+	want := "right/2 cx " + window.ProjectPlaceholder + " (" + window.ProfilePlaceholder + ") #ab12cd.2.2"
+	if got := configs[2].PickedTitle; got != want {
+		t.Errorf("unexpected picked title %q, want %q", got, want)
+	}
+}
+
+func TestBuildLaunchGroupsRejectsBadTemplate(t *testing.T) {
+	cfg := testConfig()
+	cfg.TitleTemplate = "{{.Nickname}}"
+	if _, _, err := buildLaunchGroups(cfg, testMonitors(), "ab12cd"); err == nil {
+		t.Errorf("expected an error for an unknown template field")
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/window"
+	"gopkg.in/yaml.v3"
+)
+
+// answersPath is the --answers file shared by set and all
+var answersPath string
+
+// answersFile is the YAML shape of an --answers file. Every key is optional;
+// anything left out is prompted for.
+type answersFile struct {
+	ProjectsRoot       string           `yaml:"projectsRoot"`
+	CreateProjectsRoot *bool            `yaml:"createProjectsRoot"` // create projectsRoot if it is missing
+	Monitors           []monitorAnswers `yaml:"monitors"`
+}
+
+type monitorAnswers struct {
+	Windows *int     `yaml:"windows"`
+	Layout  string   `yaml:"layout"`
+	Tools   []string `yaml:"tools"`
+}
+
+// loadAnswers reads an answers file. An empty path returns no answers.
+// Invalid values are reported by key, e.g. "monitors[1].windows".
+func loadAnswers(path string) (*launchAnswers, error) {
+	a := &launchAnswers{}
+	if path == "" {
+		return a, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers: %w", err)
+	}
+
+	var f answersFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	a.file, a.fromFile = path, map[string]bool{}
+	a.projects = config.ExpandPath(strings.TrimSpace(f.ProjectsRoot))
+	a.fromFile["projectsRoot"] = a.projects != ""
+	a.createRoot = f.CreateProjectsRoot
+	a.monitors = len(f.Monitors)
+	for i, m := range f.Monitors {
+		key := fmt.Sprintf("monitors[%d]", i)
+
+		windows := 0
+		if m.Windows != nil {
+			if *m.Windows < 1 {
+				return nil, fmt.Errorf("%s: %s.windows: must be at least 1, got %d", path, key, *m.Windows)
+			}
+			windows = *m.Windows
+		}
+
+		layout := strings.ToLower(m.Layout)
+		if layout != "" && !window.IsLayout(layout) {
+			return nil, fmt.Errorf("%s: %s.layout: unknown layout %q (available: %s)", path, key, m.Layout, strings.Join(window.Layouts, ", "))
+		}
+
+		var tools []string
+		for j, t := range m.Tools {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "cc" && t != "cx" {
+				return nil, fmt.Errorf("%s: %s.tools[%d]: unknown tool %q (use cc or cx)", path, key, j, m.Tools[j])
+			}
+			tools = append(tools, t)
+		}
+		if windows > 0 && len(tools) > 0 && windows != len(tools) {
+			return nil, fmt.Errorf("%s: %s.tools: %d tools given for %d windows", path, key, len(tools), windows)
+		}
+
+		a.windows = append(a.windows, windows)
+		a.layouts = append(a.layouts, layout)
+		a.tools = append(a.tools, tools)
+		a.fromFile[fmt.Sprintf("windows[%d]", i)] = windows > 0
+		a.fromFile[fmt.Sprintf("layout[%d]", i)] = layout != ""
+		a.fromFile[fmt.Sprintf("tools[%d]", i)] = len(tools) > 0
+	}
+	return a, nil
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeAnswers(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAnswers(t *testing.T) {
+	path := writeAnswers(t, `
+projectsRoot: /projects
+createProjectsRoot: false
+monitors:
+  - windows: 1
+  - layout: Horizontal
+    tools: [cc, CX]
+`)
+	a, err := loadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.projects != "/projects" || a.createRoot == nil || *a.createRoot {
+		t.Errorf("unexpected projects answers %q, %v", a.projects, a.createRoot)
+	}
+	if err := a.validate(2); err != nil {
+		t.Fatal(err)
+	}
+	if a.windowsFor(0) != 1 || a.windowsFor(1) != 2 || a.layoutFor(1) != "horizontal" || a.toolFor(1, 1) != "cx" {
+		t.Errorf("unexpected monitor answers %+v", a)
+	}
+
+	if err := a.validate(1); err == nil || !strings.Contains(err.Error(), "monitors") {
+		t.Errorf("expected error for more monitors than detected, got %v", err)
+	}
+}
+
+func TestLoadAnswersNamesBadKeys(t *testing.T) {
+	tests := map[string]string{
+		"monitors:\n  - windows: 0\n":                      "monitors[0].windows",
+		"monitors:\n  - {}\n  - layout: tiled\n":           "monitors[1].layout",
+		"monitors:\n  - tools: [cc, vim]\n":                "monitors[0].tools[1]",
+		"monitors:\n  - windows: 3\n    tools: [cc, cx]\n": "monitors[0].tools",
+		"monitors:\n  - windows: two\n":                    "two",
+		"projectRoot: /typo\n":                             "projectRoot",
+	}
+	for content, key := range tests {
+		_, err := loadAnswers(writeAnswers(t, content))
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("%q: expected error naming %s, got %v", content, key, err)
+		}
+	}
+}
+
+func TestLoadAnswersEmpty(t *testing.T) {
+	a, err := loadAnswers("")
+	if err != nil || a.windowsFor(0) != 0 || a.projects != "" {
+		t.Errorf("expected no answers, got %+v, %v", a, err)
+	}
+	if _, err := loadAnswers(writeAnswers(t, "")); err != nil {
+		t.Errorf("empty answers file: %v", err)
+	}
+}
+
+func TestAnswersOverride(t *testing.T) {
+	file := &launchAnswers{
+		projects: "/file",
+		windows:  []int{2, 2},
+		layouts:  []string{"vertical", "vertical"},
+		tools:    [][]string{{"cc", "cx"}, {"cx", "cx"}},
+	}
+	flags := &launchAnswers{
+		windows: []int{3},
+		layouts: []string{"", "horizontal"},
+		tools:   [][]string{nil, {"cc"}},
+	}
+	file.override(flags)
+	if err := file.validate(2); err != nil {
+		t.Fatal(err)
+	}
+
+	if file.projects != "/file" {
+		t.Errorf("expected projects from the file, got %q", file.projects)
+	}
+	// Monitor 1: count from flags drops the file's tools that no longer fit
+	if file.windowsFor(0) != 3 || file.toolFor(0, 1) != "" || file.layoutFor(0) != "vertical" {
+		t.Errorf("monitor 1: unexpected answers %+v", file)
+	}
+	// Monitor 2: tools from flags imply the count
+	if file.windowsFor(1) != 1 || file.toolFor(1, 0) != "cc" || file.layoutFor(1) != "horizontal" {
+		t.Errorf("monitor 2: unexpected answers %+v", file)
+	}
+}
+
+func TestAnswersKeys(t *testing.T) {
+	path := writeAnswers(t, "monitors:\n  - layout: grid\n    tools: [cc, cx]\n  - windows: 1\n")
+	a, err := loadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.override(&launchAnswers{windows: []int{0, 2}})
+
+	if got, want := a.key("tools", 0), path+": monitors[0].tools"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := a.key("windows", 1); got != "--windows" {
+		t.Errorf("expected a count from flags to name --windows, got %q", got)
+	}
+	if got := a.key("layout", 1); got != "--layout" {
+		t.Errorf("expected an answer given nowhere to name its flag, got %q", got)
+	}
+}
+
+func TestCheckRootNamesItsKey(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	path := writeAnswers(t, "projectsRoot: "+missing+"\n")
+	a, err := loadAnswers(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.checkRoot(); err == nil || !strings.HasPrefix(err.Error(), path+": projectsRoot: ") {
+		t.Errorf("expected the error to name the answers file key, got %v", err)
+	}
+
+	a.override(&launchAnswers{projects: missing})
+	if err := a.checkRoot(); err == nil || !strings.HasPrefix(err.Error(), "--projects: ") {
+		t.Errorf("expected the error to name the flag, got %v", err)
+	}
+
+	yes := true
+	a.createRoot = &yes
+	if err := a.checkRoot(); err != nil {
+		t.Errorf("expected the root to be created, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <plan.json>",
+	Short: "Launch the windows of a plan saved with all --dry-run",
+	Long: `Launch the windows of a plan saved with all --dry-run.
+
+The windows are launched from the plan's Groups. Its Windows and Commands
+only show what that launches, so a plan whose Windows or Commands were
+edited is refused; edit the Groups instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	plan, err := loadPlan(args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = &config.Config{}
+	}
+
+	// Plans never carry API keys; take the profiles from the config instead
+	for i := range plan.Groups {
+		for j := range plan.Groups[i].Configs {
+			plan.Groups[i].Configs[j].Profiles = cfg.Profiles
+		}
+	}
+
+	cfg.Terminal = plan.Terminal
+	l, err := window.NewLauncher(cfg)
+	if err != nil {
+		return err
+	}
+	if plan.Edited(l) {
+		return fmt.Errorf("%s does not match what its Groups launch now; apply only reads Groups, so edit those or make the plan again", args[0])
+	}
+
+	if _, ok := l.(window.Placer); ok {
+		if monitors, err := monitor.Detect(); err == nil {
+			if c := monitor.Diff(plan.Monitors, monitors); !c.Empty() {
+				ui.Warn(fmt.Sprintf("Monitors changed since the plan was made (%s)", c))
+			}
+		}
+	}
+
+	// Each apply is a run of its own, with its own run tags
+	return applyPlan(cmd.Context(), l, plan.Rerun(l, window.NewRunID()))
+}
+
+// writePlan prints plan as JSON, or writes it to path when one is given
+func writePlan(plan *window.Plan, path string) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+	data = append(data, '\n')
+
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	ui.Fin(fmt.Sprintf("Plan written to %s", path))
+	return nil
+}
+
+func loadPlan(path string) (*window.Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	plan := &window.Plan{}
+	if err := json.Unmarshal(data, plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	if plan.RunID == "" || len(plan.Groups) == 0 {
+		return nil, fmt.Errorf("%s is not a launch plan", path)
+	}
+	return plan, nil
+}
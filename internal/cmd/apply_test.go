@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/pflag"
+)
+
+func TestPlanFileRoundTrip(t *testing.T) {
+	cfg := testConfig()
+	plan, err := planLaunch(&window.WindowsTerminal{}, cfg, testMonitors())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := writePlan(plan, path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadPlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RunID != plan.RunID || len(got.Windows) != 3 || len(got.Commands) != 2 {
+		t.Errorf("unexpected plan after round trip %+v", got)
+	}
+}
+
+func TestApplyRefusesEditedPlans(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	plan, err := planLaunch(&window.Tmux{}, testConfig(), testMonitors())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(home, "plan.json")
+	if err := writePlan(plan, path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadPlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Edited(&window.Tmux{}) {
+		t.Fatalf("a plan read back should match its groups")
+	}
+
+	plan.Windows[0].Argv = append(plan.Windows[0].Argv, "--loop")
+	if err := writePlan(plan, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := runApply(applyCmd, []string{path}); err == nil || !strings.Contains(err.Error(), "Groups") {
+		t.Errorf("expected an edited plan to be refused, got %v", err)
+	}
+}
+
+func TestLoadPlanRejectsOtherJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "other.json")
+	if err := os.WriteFile(path, []byte(`{"version": 4}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadPlan(path); err == nil {
+		t.Errorf("expected an error for a file that is not a plan")
+	}
+}
+
+func TestDryRunPrintsOnlyThePlan(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	cfg := testConfig()
+	cfg.Terminal = "tmux"
+	if err := config.Save(cfg, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	allYes, allDryRun = true, true
+	t.Cleanup(func() { allYes, allDryRun = false, false })
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout, stderr, uiOut := os.Stdout, os.Stderr, ui.Out
+	os.Stdout, os.Stderr, ui.Out = w, devNull, w
+	t.Cleanup(func() { os.Stdout, os.Stderr, ui.Out = stdout, stderr, uiOut })
+	out := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- data
+	}()
+
+	runErr := runAll(allCmd, nil)
+	w.Close()
+	data := <-out
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+
+	plan := &window.Plan{}
+	if err := json.Unmarshal(data, plan); err != nil {
+		t.Fatalf("stdout is not a plan: %v\n%s", err, data)
+	}
+	if plan.RunID == "" || len(plan.Groups) == 0 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+}
+
+func TestAllBinaryTakesAllFlags(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	cfg := testConfig()
+	cfg.Terminal = "tmux"
+	if err := config.Save(cfg, ""); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		rootCmd.AddCommand(allCmd)
+		allCmd.Flags().VisitAll(func(f *pflag.Flag) {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	})
+
+	out := filepath.Join(home, "plan.json")
+	if err := execute("all", []string{"--yes", "--dry-run", "-o", out}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := &window.Plan{}
+	if err := json.Unmarshal(data, plan); err != nil || len(plan.Groups) == 0 {
+		t.Errorf("unexpected plan %s, %v", data, err)
+	}
+}
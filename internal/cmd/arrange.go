@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/registry"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+var arrangeTimeout time.Duration
+
+var arrangeCmd = &cobra.Command{
+	Use:   "arrange",
+	Short: "Move running windows back into the configured layout",
+	RunE:  runArrange,
+}
+
+func init() {
+	arrangeCmd.Flags().DurationVar(&arrangeTimeout, "timeout", time.Second, "how long to look for windows before reporting them missing")
+}
+
+func runArrange(cmd *cobra.Command, args []string) error {
+	cfg, placer, err := loadPlacer()
+	if err != nil {
+		return err
+	}
+
+	run, err := loadRun()
+	if err != nil {
+		return err
+	}
+
+	monitors, err := monitor.Detect()
+	if err != nil {
+		return fmt.Errorf("failed to detect monitors: %w", err)
+	}
+
+	ui.Head(fmt.Sprintf("Arranging run %s %s %d monitors", run.ID, ui.Dot, len(monitors)))
+	fmt.Println()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), arrangeTimeout)
+	defer cancel()
+	moved, total := reapplyLayout(ctx, placer, cfg, run, monitors)
+
+	fmt.Println()
+	if total > 0 && moved == 0 {
+		return fmt.Errorf("no windows from run %s are open", run.ID)
+	}
+	if moved < total {
+		ui.Warn(fmt.Sprintf("%d of %d windows not found", total-moved, total))
+	}
+	ui.Fin(fmt.Sprintf("Arranged %d windows", moved))
+	return nil
+}
+
+// loadPlacer loads the config and its launcher, which must be able to move
+// windows it did not just open
+func loadPlacer() (*config.Config, window.Placer, error) {
+	cfg, err := config.Load("")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("no config found — run %s set first", ActiveLabel)
+		}
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	l, err := window.NewLauncher(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	placer, ok := l.(window.Placer)
+	if !ok {
+		return nil, nil, fmt.Errorf("terminal %q does not support repositioning windows", l.Name())
+	}
+	return cfg, placer, nil
+}
+
+// loadRun returns the last recorded run, falling back to the run tag in the
+// titles on screen when nothing was recorded
+func loadRun() (*registry.Run, error) {
+	run, err := registry.Load("")
+	if err == nil {
+		return run, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	windows, err := window.ListWindows()
+	if err != nil {
+		return nil, fmt.Errorf("no launched windows recorded — run %s all first", ActiveLabel)
+	}
+	return runFromTitles(windows)
+}
+
+// runFromTitles finds the single run whose tagged windows are open
+func runFromTitles(windows []window.WindowInfo) (*registry.Run, error) {
+	ids := map[string]bool{}
+	for _, w := range windows {
+		if id := window.RunIDOf(w.Title); id != "" {
+			ids[id] = true
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no launched windows found — run %s all first", ActiveLabel)
+	case 1:
+		for id := range ids {
+			return &registry.Run{ID: id}, nil
+		}
+	}
+	return nil, fmt.Errorf("windows from %d runs are open and none is recorded — run %s all again", len(ids), ActiveLabel)
+}
+
+// reapplyLayout recomputes positions for the windows of run on the given
+// monitors and moves any that are open, reporting the ones it cannot find.
+// It returns how many windows were moved out of how many are laid out.
+func reapplyLayout(ctx context.Context, p window.Placer, cfg *config.Config, run *registry.Run, monitors []monitor.Monitor) (moved, total int) {
+	_, configs, err := buildLaunchGroups(cfg, monitors, run.ID)
+	if err != nil {
+		ui.Warn(err.Error())
+		return 0, 0
+	}
+	if len(configs) == 0 {
+		ui.Warn("No configured monitors are connected")
+		return 0, 0
+	}
+
+	for _, r := range window.Reposition(ctx, p, configs) {
+		if r.Err != nil {
+			ui.Item(r.Title, false)
+			ui.Warn(r.Err.Error())
+			continue
+		}
+		ui.Item(r.Title, true)
+		moved++
+	}
+	return moved, len(configs)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bcmister/cc/internal/registry"
+	"github.com/bcmister/cc/internal/window"
+)
+
+func TestReapplyLayoutMovesOpenWindows(t *testing.T) {
+	rec := &window.RecordingLauncher{
+		Errors: map[string]error{"cx-2-2 #ab12cd.2.2": errors.New("not found")},
+	}
+	moved, total := reapplyLayout(context.Background(), rec, testConfig(), &registry.Run{ID: "ab12cd"}, testMonitors())
+	if moved != 2 || total != 3 {
+		t.Errorf("expected 2 of 3 windows moved, got %d of %d", moved, total)
+	}
+
+	placed := rec.CallsTo("Place")
+	if len(placed) != 3 {
+		t.Fatalf("expected every window to be placed, got %d", len(placed))
+	}
+	// First window on monitor 2 goes back to the left half
+	if got := placed[1].Spec.Position; got != (window.Position{X: 1536, Y: 0, Width: 960, Height: 1080}) {
+		t.Errorf("cc-2-1: unexpected position %+v", got)
+	}
+	if len(rec.CallsTo("NewWindow")) != 0 {
+		t.Errorf("arrange must not open windows")
+	}
+}
+
+func TestReapplyLayoutSkipsMissingMonitors(t *testing.T) {
+	rec := &window.RecordingLauncher{}
+	moved, total := reapplyLayout(context.Background(), rec, testConfig(), &registry.Run{ID: "ab12cd"}, testMonitors()[:1])
+	if moved != 1 || total != 1 {
+		t.Errorf("expected only monitor 1's window, got %d of %d", moved, total)
+	}
+}
+
+func TestRunFromTitles(t *testing.T) {
+	run, err := runFromTitles([]window.WindowInfo{
+		{Title: "Windows PowerShell"},
+		{Title: "cc-1-1 #ab12cd.1.1"},
+		{Title: "api · cc #ab12cd.2.1"},
+	})
+	if err != nil || run.ID != "ab12cd" {
+		t.Fatalf("expected run ab12cd, got %+v, %v", run, err)
+	}
+
+	if _, err := runFromTitles([]window.WindowInfo{{Title: "Windows PowerShell"}}); err == nil {
+		t.Errorf("expected an error with no tagged windows")
+	}
+	if _, err := runFromTitles([]window.WindowInfo{{Title: "cc-1-1 #ab12cd.1.1"}, {Title: "cc-1-1 #ff00aa.1.1"}}); err == nil {
+		t.Errorf("expected an error with windows from two runs")
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/picker"
+	"github.com/bcmister/cc/internal/project"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneProfile  string
+	cloneNoLaunch bool
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <url> [name]",
+	Short: "Clone a repository into the projects root and start the tool in it",
+	Long: `Clone a repository into the projects root and start the tool in it.
+
+The repository can be any git remote or local repository, or a shorthand
+such as gh:team/repo. With clone.defaultOrg set in the config, a bare
+repository name is cloned from there.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runClone,
+}
+
+func init() {
+	cloneCmd.Flags().StringVar(&cloneProfile, "profile", "", "account profile to start the tool with (defaults to the first)")
+	cloneCmd.Flags().BoolVar(&cloneNoLaunch, "no-launch", false, "only clone, without starting the tool")
+}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = &config.Config{ProjectsRoot: config.DefaultProjectsRoot()}
+	}
+
+	remote, ok := git.Remote(args[0], cfg.Clone.Shorthands, cfg.Clone.DefaultOrg)
+	if !ok {
+		return fmt.Errorf("%s is not a repository URL, local repository or known shorthand", args[0])
+	}
+	name := git.RepoName(remote)
+	if len(args) == 2 {
+		name = args[1]
+	}
+	if !project.ValidName(name) {
+		return fmt.Errorf("invalid project name %q", name)
+	}
+	dir := filepath.Join(cfg.ProjectsRoot, name)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already exists", dir)
+	}
+
+	profile, err := findProfile(cfg, cloneProfile)
+	if err != nil {
+		return err
+	}
+
+	ui.Head(fmt.Sprintf("Cloning %s", remote))
+	fmt.Println()
+	if err := git.Clone(cmd.Context(), remote, dir, os.Stderr); err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Cloned into %s", dir))
+	if cloneNoLaunch {
+		fmt.Println()
+		return nil
+	}
+
+	title, err := projectTitle(cfg, ActiveLabel, name, profile)
+	if err != nil {
+		return err
+	}
+
+	s := picker.Session{
+		Dir:      cfg.ProjectsRoot,
+		Command:  ActiveCommand,
+		Label:    ActiveLabel,
+		Title:    title,
+		Profiles: cfg.Profiles,
+		CdFile:   cdFile,
+		Loop:     cfg.Loop,
+	}
+	s.Open = window.Opener(s)
+	return picker.Start(picker.Stdio(), s, picker.Result{Project: name, Profile: profile})
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/export"
+	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+	exportLaunch bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Generate a Zellij, WezTerm or kitty session from the config",
+	RunE:  runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "output format: "+strings.Join(export.Names(), ", "))
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write to this file instead of stdout")
+	exportCmd.Flags().BoolVar(&exportLaunch, "launch", false, "start the terminal with the generated file")
+	exportCmd.MarkFlagRequired("format")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := export.Lookup(exportFormat)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no config found — run %s set first", ActiveLabel)
+		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Exported terminals place their own windows, so monitor geometry is
+	// only a hint; fall back to one placeholder per configured monitor
+	monitors, err := monitor.Detect()
+	if err != nil {
+		monitors = placeholderMonitors(cfg)
+	}
+
+	groups, _, err := buildLaunchGroups(cfg, monitors, window.NewRunID())
+	if err != nil {
+		return err
+	}
+	content := format.Render(groups)
+
+	path := exportOutput
+	if path == "" && exportLaunch {
+		path = filepath.Join(filepath.Dir(config.DefaultConfigPath()), "sessions", "cc"+format.Ext)
+	}
+	if path == "" {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if !exportLaunch {
+		ui.Ok(fmt.Sprintf("Wrote %s session", format.Name))
+		fmt.Printf("   %s%s %s%s\n\n", ui.DkGray, ui.Arrow, path, ui.Reset)
+		return nil
+	}
+
+	argv := format.LaunchArgv(path)
+	c := exec.Command(argv[0], argv[1:]...)
+	if format.Foreground {
+		c.Stdin = os.Stdin
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", format.Name, err)
+	}
+	ui.Ok(fmt.Sprintf("Started %s", format.Name))
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bcmister/cc/internal/history"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the projects picked most, most frecent first",
+	Args:  cobra.NoArgs,
+	RunE:  runHistory,
+}
+
+var historyClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Forget every picked project",
+	Args:  cobra.NoArgs,
+	RunE:  runHistoryClear,
+}
+
+func init() {
+	historyCmd.AddCommand(historyClearCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	h, err := history.Load("")
+	if err != nil {
+		return err
+	}
+
+	if len(h.Entries) == 0 {
+		fmt.Printf("\n %sNo projects picked yet.%s\n\n", ui.DkGray, ui.Reset)
+		return nil
+	}
+
+	now := time.Now()
+	ui.Head(fmt.Sprintf("%d project(s)", len(h.Entries)))
+	fmt.Println()
+	for _, e := range h.Ranked(now) {
+		fmt.Printf("   %s%6.1f%s  %s%-40s%s %s%3d× %s%s\n",
+			ui.BrCyan, e.Frecency(now), ui.Reset,
+			ui.BrWhite, e.Path, ui.Reset,
+			ui.DkGray, e.Count, ago(now.Sub(e.Last)), ui.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runHistoryClear(cmd *cobra.Command, args []string) error {
+	if err := history.Clear(""); err != nil {
+		return err
+	}
+	ui.Fin("History cleared")
+	return nil
+}
+
+// ago formats d the way a human would say how long ago something was
+func ago(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
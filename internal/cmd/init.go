@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/bcmister/cc/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+var initName string
+
+var initCmd = &cobra.Command{
+	Use:   "init <bash|zsh|fish|pwsh>",
+	Short: "Print shell integration that cds to the picked project",
+	Long: `Print shell integration that cds to the picked project.
+
+The script defines a function wrapping cc, so the shell ends up in the
+project the tool ran in once it exits, and a second one with a "d" on the
+end that only picks a project and cds to it. Add it to the shell's startup:
+
+  bash   eval "$(cc init bash)"                    in ~/.bashrc
+  zsh    eval "$(cc init zsh)"                     in ~/.zshrc
+  fish   cc init fish | source                     in ~/.config/fish/config.fish
+  pwsh   Invoke-Expression (& cc init pwsh | Out-String)   in $PROFILE`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish", "pwsh"},
+	RunE:      runInit,
+}
+
+func init() {
+	initCmd.Flags().StringVar(&initName, "cmd", "", "name of the wrapper function (defaults to the binary's name)")
+}
+
+// shellScript holds what a shell integration script is rendered from
+type shellScript struct {
+	Cmd string // wrapper function name
+	Bin string // binary the wrapper runs
+	Env string // variable naming the file the directory comes back in
+}
+
+// posixScript serves both bash and zsh
+const posixScript = `# {{.Cmd}} shell integration
+__{{.Cmd}}_run() {
+    local file ret dir
+    file="$(mktemp)" || return
+    {{.Env}}="$file" command {{.Bin}} "$@"
+    ret=$?
+    dir="$(cat -- "$file")"
+    rm -f -- "$file"
+    if [ -n "$dir" ] && [ "$dir" != "$PWD" ]; then
+        builtin cd -- "$dir" || return
+    fi
+    return $ret
+}
+
+{{.Cmd}}() {
+    __{{.Cmd}}_run "$@"
+}
+
+{{.Cmd}}d() {
+    __{{.Cmd}}_run pick --no-launch "$@"
+}
+`
+
+const fishScript = `# {{.Cmd}} shell integration
+function __{{.Cmd}}_run
+    set -l file (mktemp); or return
+    {{.Env}}=$file command {{.Bin}} $argv
+    set -l ret $status
+    set -l dir (cat -- $file)
+    rm -f -- $file
+    if test -n "$dir"; and test "$dir" != "$PWD"
+        builtin cd -- $dir; or return
+    end
+    return $ret
+end
+
+function {{.Cmd}}
+    __{{.Cmd}}_run $argv
+end
+
+function {{.Cmd}}d
+    __{{.Cmd}}_run pick --no-launch $argv
+end
+`
+
+const pwshScript = `# {{.Cmd}} shell integration
+function global:__{{.Cmd}}_run {
+    $file = [System.IO.Path]::GetTempFileName()
+    $env:{{.Env}} = $file
+    try {
+        $bin = Get-Command -Name '{{.Bin}}' -CommandType Application | Select-Object -First 1
+        & $bin @args
+        $ret = $LASTEXITCODE
+    } finally {
+        Remove-Item Env:{{.Env}} -ErrorAction SilentlyContinue
+    }
+    $dir = Get-Content -LiteralPath $file -Raw -ErrorAction SilentlyContinue
+    Remove-Item -LiteralPath $file -ErrorAction SilentlyContinue
+    if ($dir -and $dir -ne $PWD.Path) {
+        Set-Location -LiteralPath $dir
+    }
+    $global:LASTEXITCODE = $ret
+}
+
+function global:{{.Cmd}} {
+    __{{.Cmd}}_run @args
+}
+
+function global:{{.Cmd}}d {
+    __{{.Cmd}}_run pick --no-launch @args
+}
+`
+
+var shellScripts = map[string]string{
+	"bash": posixScript,
+	"zsh":  posixScript,
+	"fish": fishScript,
+	"pwsh": pwshScript,
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	bin := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	name := initName
+	if name == "" {
+		name = bin
+	}
+	script, err := renderShellScript(args[0], shellScript{Cmd: name, Bin: bin, Env: picker.CdFileEnv})
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// renderShellScript returns the integration script for shell
+func renderShellScript(shell string, s shellScript) (string, error) {
+	text, ok := shellScripts[strings.ToLower(shell)]
+	if !ok {
+		return "", fmt.Errorf("unknown shell %q (use bash, zsh, fish or pwsh)", shell)
+	}
+	var b strings.Builder
+	if err := template.Must(template.New(shell).Parse(text)).Execute(&b, s); err != nil {
+		return "", fmt.Errorf("failed to render %s script: %w", shell, err)
+	}
+	return b.String(), nil
+}
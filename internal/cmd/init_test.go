@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRenderShellScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "pwsh"} {
+		got, err := renderShellScript(shell, shellScript{Cmd: "cx", Bin: "cx", Env: "CC_CD_FILE"})
+		if err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+		for _, want := range []string{"__cx_run", "cxd", "CC_CD_FILE", "pick --no-launch"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: expected %q in:\n%s", shell, want, got)
+			}
+		}
+	}
+	if _, err := renderShellScript("tcsh", shellScript{}); err == nil {
+		t.Error("expected an error for an unknown shell")
+	}
+}
+
+func TestBashIntegrationChangesDirectory(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil || runtime.GOOS == "windows" {
+		t.Skip("bash not installed")
+	}
+
+	// A stand-in for cc that reports its arguments and picks target
+	bin, target := t.TempDir(), t.TempDir()
+	fake := "#!/bin/sh\necho \"$@\"\nprintf %s \"$TARGET\" > \"$CC_CD_FILE\"\nexit 3\n"
+	if err := os.WriteFile(filepath.Join(bin, "fakecc"), []byte(fake), 0755); err != nil {
+		t.Fatal(err)
+	}
+	script, err := renderShellScript("bash", shellScript{Cmd: "fakecc", Bin: "fakecc", Env: "CC_CD_FILE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bash, "--norc", "-c", script+"\nfakeccd --dir x\necho \"$? $PWD\"")
+	cmd.Env = append(os.Environ(), "PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"), "TARGET="+target)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	if want := "pick --no-launch --dir x\n3 " + target + "\n"; string(out) != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
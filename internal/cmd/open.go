@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/fuzzy"
+	"github.com/bcmister/cc/internal/picker"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+// maxSuggestions is how many candidates an ambiguous query lists
+const maxSuggestions = 5
+
+var (
+	openProfile string
+	openTool    string
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open <query>",
+	Short: "Start the tool in the project matching query, without the picker",
+	Long: `Start the tool in the project matching query, without the picker.
+
+The query is matched the way the picker filters: a project named exactly
+like it wins, otherwise it has to match a single project.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOpen,
+}
+
+func init() {
+	openCmd.Flags().StringVar(&openProfile, "profile", "", "account profile to use (defaults to the first)")
+	openCmd.Flags().StringVar(&openTool, "tool", "", `tool to start, "cc" or "cx", instead of the default or the project's override`)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	if openTool != "" && openTool != "cc" && openTool != "cx" {
+		return fmt.Errorf("unknown tool %q (use cc or cx)", openTool)
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = &config.Config{ProjectsRoot: config.DefaultProjectsRoot()}
+	}
+
+	projects, err := picker.ListProjects(cfg.ProjectsRoot)
+	if err != nil {
+		return err
+	}
+	name, err := resolveProject(args[0], projects)
+	if err != nil {
+		return err
+	}
+	profile, err := findProfile(cfg, openProfile)
+	if err != nil {
+		return err
+	}
+
+	label := ActiveLabel
+	if openTool != "" {
+		label = config.LabelFor(openTool)
+	}
+	title, err := projectTitle(cfg, label, name, profile)
+	if err != nil {
+		return err
+	}
+
+	s := picker.Session{
+		Dir:      cfg.ProjectsRoot,
+		Command:  ActiveCommand,
+		Label:    label,
+		Title:    title,
+		Profiles: cfg.Profiles,
+		CdFile:   cdFile,
+		Loop:     cfg.Loop,
+	}
+	s.Open = window.Opener(s)
+	return picker.Start(picker.Stdio(), s, picker.Result{Project: name, Profile: profile, Tool: openTool})
+}
+
+// resolveProject returns the project query names: the one called query,
+// ignoring case, or else the only one it fuzzy matches
+func resolveProject(query string, projects []string) (string, error) {
+	for _, p := range projects {
+		if strings.EqualFold(p, query) {
+			return p, nil
+		}
+	}
+
+	matches := fuzzy.Find(query, projects, nil)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no project matches %q", query)
+	case 1:
+		return projects[matches[0].Index], nil
+	}
+
+	names := make([]string, 0, maxSuggestions)
+	for _, m := range matches[:min(len(matches), maxSuggestions)] {
+		names = append(names, projects[m.Index])
+	}
+	more := ""
+	if len(matches) > maxSuggestions {
+		more = fmt.Sprintf(" and %d more", len(matches)-maxSuggestions)
+	}
+	return "", fmt.Errorf("%q matches %d projects: %s%s", query, len(matches), strings.Join(names, ", "), more)
+}
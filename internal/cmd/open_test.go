@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveProject(t *testing.T) {
+	projects := []string{"api", "api-old", "api-v2", "billing-service", "web"}
+
+	for query, want := range map[string]string{
+		"api":  "api", // an exact name beats the longer matches
+		"WEB":  "web",
+		"bsvc": "billing-service",
+		"apv2": "api-v2",
+	} {
+		if got, err := resolveProject(query, projects); err != nil || got != want {
+			t.Errorf("%q: got %q, %v; want %q", query, got, err, want)
+		}
+	}
+
+	_, err := resolveProject("ap", projects)
+	if err == nil || !strings.Contains(err.Error(), "api, api-old, api-v2") {
+		t.Errorf("expected the candidates to be suggested, got %v", err)
+	}
+	if _, err := resolveProject("zzz", projects); err == nil {
+		t.Error("expected an error when nothing matches")
+	}
+}
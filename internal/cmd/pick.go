@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/picker"
+	"github.com/bcmister/cc/internal/window"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pickDir     string
+	pickCommand string
+	pickLabel   string
+	pickTitle   string
+
+	pickProject  string
+	pickProfile  string
+	pickWorktree bool
+	pickNoLaunch bool
+	pickLoop     bool
+)
+
+// pickCmd is what each launched window runs: the project picker, which then
+// becomes the chosen tool
+var pickCmd = &cobra.Command{
+	Use:    "pick",
+	Short:  "Pick a project and start the tool in it",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runPick,
+}
+
+func init() {
+	pickCmd.Flags().StringVar(&pickDir, "dir", "", "projects root (defaults to the configured one)")
+	pickCmd.Flags().StringVar(&pickCommand, "command", "", "command to start in the chosen project")
+	pickCmd.Flags().StringVar(&pickLabel, "label", "", "tool name shown in the picker")
+	pickCmd.Flags().StringVar(&pickTitle, "title", "", "terminal title once a project is chosen")
+	pickCmd.Flags().StringVar(&pickProject, "project", "", "start in this project without showing the picker")
+	pickCmd.Flags().StringVar(&pickProfile, "profile", "", "account profile to use with --project")
+	pickCmd.Flags().BoolVar(&pickWorktree, "worktree", false, "start --project in a new git worktree")
+	pickCmd.Flags().BoolVar(&pickNoLaunch, "no-launch", false, "only pick a project, for the shell integration to cd to")
+	pickCmd.Flags().BoolVar(&pickLoop, "loop", false, "go back to the picker when the tool exits")
+}
+
+func runPick(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = &config.Config{ProjectsRoot: config.DefaultProjectsRoot()}
+	}
+
+	s := picker.Session{
+		Dir:      pickDir,
+		Command:  pickCommand,
+		Label:    pickLabel,
+		Title:    pickTitle,
+		Profiles: cfg.Profiles,
+		CdFile:   cdFile,
+		NoLaunch: pickNoLaunch,
+		Loop:     pickLoop,
+	}
+	if s.Dir == "" {
+		s.Dir = cfg.ProjectsRoot
+	}
+	if s.Command == "" {
+		s.Command = ActiveCommand
+	}
+	if s.Label == "" {
+		s.Label = ActiveLabel
+	}
+
+	// Loop mode can come back to the picker from --project too
+	if !s.NoLaunch {
+		s.Open = window.Opener(s)
+	}
+	if pickProject != "" {
+		profile, err := findProfile(cfg, pickProfile)
+		if err != nil {
+			return err
+		}
+		return picker.Start(picker.Stdio(), s, picker.Result{Project: pickProject, Profile: profile, Worktree: pickWorktree})
+	}
+	return picker.Pick(picker.Stdio(), s)
+}
+
+// projectTitle renders the configured window title for starting label's
+// tool in project with profile
+func projectTitle(cfg *config.Config, label, project string, profile *config.Profile) (string, error) {
+	tmpl, err := window.ParseTitleTemplate(cfg.TitleTemplate)
+	if err != nil {
+		return "", err
+	}
+	f := window.TitleFields{Tool: label, Project: project}
+	if profile != nil && cfg.HasProfiles() {
+		f.Profile = profile.Name
+	}
+	return tmpl.Render(f)
+}
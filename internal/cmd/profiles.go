@@ -199,3 +199,25 @@ func runProfilesRemove(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	return nil
 }
+
+// findProfile returns the profile called name, or the first profile when
+// name is empty. It returns nil when there are no profiles to choose from.
+func findProfile(cfg *config.Config, name string) (*config.Profile, error) {
+	if name == "" {
+		if len(cfg.Profiles) == 0 {
+			return nil, nil
+		}
+		return &cfg.Profiles[0], nil
+	}
+	names := make([]string, len(cfg.Profiles))
+	for i := range cfg.Profiles {
+		if strings.EqualFold(cfg.Profiles[i].Name, name) {
+			return &cfg.Profiles[i], nil
+		}
+		names[i] = cfg.Profiles[i].Name
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("profile %q not found: no profiles are configured", name)
+	}
+	return nil, fmt.Errorf("profile %q not found (have %s)", name, strings.Join(names, ", "))
+}
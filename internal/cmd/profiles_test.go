@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+)
+
+func TestFindProfile(t *testing.T) {
+	cfg := &config.Config{Profiles: []config.Profile{{Name: "work"}, {Name: "personal"}}}
+
+	if p, err := findProfile(cfg, ""); err != nil || p.Name != "work" {
+		t.Errorf("empty name: got %v, %v; want the first profile", p, err)
+	}
+	if p, err := findProfile(cfg, "Personal"); err != nil || p.Name != "personal" {
+		t.Errorf("got %v, %v; want personal", p, err)
+	}
+	if _, err := findProfile(cfg, "other"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+	if p, err := findProfile(&config.Config{}, ""); err != nil || p != nil {
+		t.Errorf("no profiles: got %v, %v; want nil", p, err)
+	}
+}
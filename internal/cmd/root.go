@@ -8,6 +8,7 @@ import (
 
 	"github.com/bcmister/cc/internal/config"
 	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/picker"
 	"github.com/bcmister/cc/internal/ui"
 	"github.com/bcmister/cc/internal/window"
 	"github.com/spf13/cobra"
@@ -18,6 +19,14 @@ var (
 	ActiveLabel   string
 )
 
+// rootLoop is the bare command's --loop flag
+var rootLoop bool
+
+// cdFile is where the shell integration wants the chosen directory. It is
+// taken out of the environment so windows launched from here do not write
+// to it too.
+var cdFile string
+
 var rootCmd = &cobra.Command{
 	Use:   "cc",
 	Short: "Quick project picker for terminal",
@@ -25,25 +34,46 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() error {
-	bin := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	return execute(strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe"), os.Args[1:])
+}
+
+// execute runs the command line args as binary bin
+func execute(bin string, args []string) error {
 	ActiveLabel = config.LabelFor(bin)
 	ActiveCommand = config.CommandFor(bin)
 	rootCmd.Use = ActiveLabel
+	cdFile = os.Getenv(picker.CdFileEnv)
+	os.Unsetenv(picker.CdFileEnv)
 
-	// Busybox dispatch: when invoked as "all", run the wizard directly
+	// Busybox dispatch: when invoked as "all", the wizard is the whole
+	// program, flags and all
 	if bin == "all" {
-		rootCmd.RunE = runAll
+		rootCmd.RemoveCommand(allCmd)
+		allCmd.SetArgs(args)
+		return allCmd.Execute()
 	}
 
+	rootCmd.SetArgs(args)
 	return rootCmd.Execute()
 }
 
 func init() {
+	rootCmd.Flags().BoolVar(&rootLoop, "loop", false, "go back to the picker when the tool exits (defaults to the config's loop)")
 	rootCmd.AddCommand(setCmd)
 	rootCmd.AddCommand(monitorsCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(allCmd)
 	rootCmd.AddCommand(profilesCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(arrangeCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(pickCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(cloneCmd)
+	rootCmd.AddCommand(worktreesCmd)
+	rootCmd.AddCommand(openCmd)
+	rootCmd.AddCommand(initCmd)
 }
 
 func runCc(cmd *cobra.Command, args []string) error {
@@ -57,8 +87,30 @@ func runCc(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 	}
+	tmpl, err := window.ParseTitleTemplate(cfg.TitleTemplate)
+	if err != nil {
+		return err
+	}
+	title, err := tmpl.Render(pickedFields(window.TitleFields{Tool: ActiveLabel}, cfg.Profiles))
+	if err != nil {
+		return err
+	}
+
+	loop := cfg.Loop
+	if cmd.Flags().Changed("loop") {
+		loop = rootLoop
+	}
+
 	// Run picker directly - no UI chrome, fastest path
-	return window.RunPickerInCurrent(cfg.ProjectsRoot, ActiveCommand, ActiveLabel, cfg.Profiles)
+	return window.RunPickerInCurrent(picker.Session{
+		Dir:      cfg.ProjectsRoot,
+		Command:  ActiveCommand,
+		Label:    ActiveLabel,
+		Title:    title,
+		Profiles: cfg.Profiles,
+		CdFile:   cdFile,
+		Loop:     loop,
+	})
 }
 
 var monitorsCmd = &cobra.Command{
@@ -19,9 +19,18 @@ var setCmd = &cobra.Command{
 	RunE:  runSet,
 }
 
+func init() {
+	setCmd.Flags().StringVar(&answersPath, "answers", "", "YAML file answering the prompts")
+}
+
 func runSet(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
 
+	answers, err := loadAnswers(answersPath)
+	if err != nil {
+		return err
+	}
+
 	existing, _ := config.Load("")
 
 	defaultRoot := config.DefaultProjectsRoot()
@@ -33,19 +42,26 @@ func runSet(cmd *cobra.Command, args []string) error {
 	ui.Sep()
 
 	// --- Projects root ---
-	ui.Prompt("Projects root", defaultRoot)
-	projectsRoot, _ := reader.ReadString('\n')
-	projectsRoot = strings.TrimSpace(projectsRoot)
+	projectsRoot := answers.projects
 	if projectsRoot == "" {
-		projectsRoot = defaultRoot
+		ui.Prompt("Projects root", defaultRoot)
+		projectsRoot, _ = reader.ReadString('\n')
+		projectsRoot = strings.TrimSpace(projectsRoot)
+		if projectsRoot == "" {
+			projectsRoot = defaultRoot
+		}
 	}
 
 	if _, err := os.Stat(projectsRoot); os.IsNotExist(err) {
-		fmt.Printf("   %sDirectory does not exist. Create it?%s %s[Y/n]%s  %s%s%s ",
-			ui.White, ui.Reset, ui.DkGray, ui.Reset, ui.BrCyan, ui.Arrow, ui.Reset)
-		answer, _ := reader.ReadString('\n')
-		answer = strings.TrimSpace(strings.ToLower(answer))
-		if answer == "" || answer == "y" || answer == "yes" {
+		create := answers.createRoot != nil && *answers.createRoot
+		if answers.createRoot == nil {
+			fmt.Printf("   %sDirectory does not exist. Create it?%s %s[Y/n]%s  %s%s%s ",
+				ui.White, ui.Reset, ui.DkGray, ui.Reset, ui.BrCyan, ui.Arrow, ui.Reset)
+			answer, _ := reader.ReadString('\n')
+			answer = strings.TrimSpace(strings.ToLower(answer))
+			create = answer == "" || answer == "y" || answer == "yes"
+		}
+		if create {
 			os.MkdirAll(projectsRoot, 0755)
 			fmt.Printf("   %s%s Created%s\n", ui.BrGreen, ui.Check, ui.Reset)
 		}
@@ -69,6 +85,9 @@ func runSet(cmd *cobra.Command, args []string) error {
 		ui.BoxRow(fmt.Sprintf("%s%d × %d%s", ui.BrWhite, m.Width, m.Height, ui.Reset))
 		ui.BoxEnd()
 	}
+	if err := answers.validate(len(monitors)); err != nil {
+		return err
+	}
 
 	// --- Windows per monitor (v3 format) ---
 	monitorConfigs := make([]config.MonitorConfig, len(monitors))
@@ -81,49 +100,57 @@ func runSet(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		ui.Prompt(fmt.Sprintf("Windows on Monitor %d", i+1), strconv.Itoa(defaultWindows))
-		windowsStr, _ := reader.ReadString('\n')
-		windowsStr = strings.TrimSpace(windowsStr)
-		windows := defaultWindows
-		if windowsStr != "" {
-			w, err := strconv.Atoi(windowsStr)
-			if err == nil && w >= 1 {
-				windows = w
+		windows := answers.windowsFor(i)
+		if windows == 0 {
+			ui.Prompt(fmt.Sprintf("Windows on Monitor %d", i+1), strconv.Itoa(defaultWindows))
+			windowsStr, _ := reader.ReadString('\n')
+			windowsStr = strings.TrimSpace(windowsStr)
+			windows = defaultWindows
+			if windowsStr != "" {
+				w, err := strconv.Atoi(windowsStr)
+				if err == nil && w >= 1 {
+					windows = w
+				}
 			}
 		}
 
-		layout := "full"
-		if windows == 2 {
-			layout = "vertical"
-		} else if windows >= 3 {
-			layout = "grid"
+		layout := answers.layoutFor(i)
+		if layout == "" {
+			layout = "full"
+			if windows == 2 {
+				layout = "vertical"
+			} else if windows >= 3 {
+				layout = "grid"
+			}
 		}
 
 		// Build WindowConfig slice, defaulting all to "cc"
 		wcs := make([]config.WindowConfig, windows)
 		for j := range wcs {
 			wcs[j] = config.WindowConfig{Tool: "cc"}
+			if tool := answers.toolFor(i, j); tool != "" {
+				wcs[j].Tool = tool
+			}
 		}
 
 		monitorConfigs[i] = config.MonitorConfig{
 			Layout:  layout,
 			Windows: wcs,
 		}
+		if existing != nil && i < len(existing.Monitors) {
+			monitorConfigs[i].Nickname = existing.Monitors[i].Nickname
+		}
 	}
 
 	// --- Save ---
-	// Preserve existing profiles, or init default
-	var profiles []config.Profile
-	if existing != nil && len(existing.Profiles) > 0 {
-		profiles = existing.Profiles
-	}
-
-	cfg := &config.Config{
-		Version:      4,
-		ProjectsRoot: projectsRoot,
-		Profiles:     profiles,
-		Monitors:     monitorConfigs,
+	// Start from the existing config so profiles and other settings survive
+	cfg := &config.Config{}
+	if existing != nil {
+		*cfg = *existing
 	}
+	cfg.Version = 4
+	cfg.ProjectsRoot = projectsRoot
+	cfg.Monitors = monitorConfigs
 
 	configPath := config.DefaultConfigPath()
 	if err := config.Save(cfg, configPath); err != nil {
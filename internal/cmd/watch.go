@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	watchDebounce time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Re-apply the window layout when monitors are connected or removed",
+	RunE:  runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "how often to poll for monitor changes")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", 3*time.Second, "how long a new monitor layout must settle before windows are moved")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	cfg, placer, err := loadPlacer()
+	if err != nil {
+		return err
+	}
+
+	run, err := loadRun()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	ui.Head("Watching for monitor changes")
+	fmt.Printf("   %s%s ctrl+c to stop%s\n", ui.DkGray, ui.Arrow, ui.Reset)
+
+	w := &monitor.Watcher{
+		Interval: watchInterval,
+		Debounce: watchDebounce,
+	}
+	return w.Watch(ctx, func(before, after []monitor.Monitor, c monitor.Change) {
+		ui.Head(fmt.Sprintf("Monitors changed (%s) %s %d connected", c, ui.Dot, len(after)))
+		fmt.Println()
+		reapplyLayout(ctx, placer, cfg, run, after)
+	})
+}
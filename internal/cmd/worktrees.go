@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var pruneDryRun bool
+
+var worktreesCmd = &cobra.Command{
+	Use:   "worktrees",
+	Short: "Manage the git worktrees windows were started in",
+	Args:  cobra.NoArgs,
+	RunE:  runWorktreesList,
+}
+
+var worktreesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the worktrees made for windows",
+	Args:  cobra.NoArgs,
+	RunE:  runWorktreesList,
+}
+
+var worktreesPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove worktrees that are merged or whose directory is gone",
+	Long: `Remove worktrees that are merged or whose directory is gone.
+
+A worktree is removed along with its branch once the branch is merged into
+the project's current branch and the tree has no changes; a tree nobody
+has committed to counts as merged. Worktrees whose directory was deleted
+are forgotten, keeping their branch unless it is merged.
+
+Worktrees a window is still running in are left alone, however they look.`,
+	Args: cobra.NoArgs,
+	RunE: runWorktreesPrune,
+}
+
+func init() {
+	worktreesPruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "only show what would be removed")
+	worktreesCmd.AddCommand(worktreesListCmd)
+	worktreesCmd.AddCommand(worktreesPruneCmd)
+}
+
+// managedWorktree is a worktree cc made for a window
+type managedWorktree struct {
+	git.Worktree
+	Project    string
+	Repo       string // the project's main working tree
+	Dirty      bool
+	Merged     bool
+	InUse      bool // locked by a window that is still running
+	LastCommit time.Time
+}
+
+// prunable reports whether prune removes w
+func (w managedWorktree) prunable() bool {
+	return !w.InUse && (w.Missing || w.Merged && !w.Dirty)
+}
+
+// reason says why prune removes w
+func (w managedWorktree) reason() string {
+	if w.Missing {
+		return "directory gone"
+	}
+	return "merged"
+}
+
+// findWorktrees returns the worktrees under parent, one directory per
+// project, that belong to the projects in root
+func findWorktrees(ctx context.Context, root, parent string) ([]managedWorktree, error) {
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", parent, err)
+	}
+
+	var found []managedWorktree
+	for _, e := range entries {
+		repo := filepath.Join(root, e.Name())
+		if !e.IsDir() || !git.IsRepo(repo) {
+			continue
+		}
+		wts, err := git.Worktrees(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(parent, e.Name())
+		for _, wt := range wts {
+			if !sameDir(filepath.Dir(wt.Path), dir) {
+				continue
+			}
+			w := managedWorktree{Worktree: wt, Project: e.Name(), Repo: repo, InUse: inUse(wt)}
+			if _, err := os.Stat(wt.Path); err != nil {
+				w.Missing = true
+			}
+			if !w.Missing {
+				s, err := git.Read(ctx, wt.Path)
+				if err != nil {
+					return nil, err
+				}
+				w.Dirty, w.LastCommit = s.Dirty, s.LastCommit
+			}
+			if wt.Branch != "" {
+				if w.Merged, err = git.Merged(ctx, repo, wt.Branch); err != nil {
+					return nil, err
+				}
+			}
+			found = append(found, w)
+		}
+	}
+	return found, nil
+}
+
+// inUse reports whether wt is locked by something other than a cc window
+// that has since exited
+func inUse(wt git.Worktree) bool {
+	if !wt.Locked {
+		return false
+	}
+	pid := git.LockHolder(wt.Reason)
+	return pid == 0 || processAlive(pid)
+}
+
+// sameDir reports whether a and b are the same directory, allowing for git
+// reporting paths with symlinks resolved
+func sameDir(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ra == rb
+}
+
+func loadWorktrees(ctx context.Context) ([]managedWorktree, error) {
+	cfg, err := config.Load("")
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		cfg = &config.Config{ProjectsRoot: config.DefaultProjectsRoot()}
+	}
+	return findWorktrees(ctx, cfg.ProjectsRoot, cfg.WorktreesDir())
+}
+
+func runWorktreesList(cmd *cobra.Command, args []string) error {
+	wts, err := loadWorktrees(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if len(wts) == 0 {
+		fmt.Printf("\n %sNo worktrees. Press %s^w%s%s in the picker to start a project in one.%s\n\n",
+			ui.DkGray, ui.BrCyan, ui.Reset, ui.DkGray, ui.Reset)
+		return nil
+	}
+
+	now := time.Now()
+	ui.Head(fmt.Sprintf("%d worktree(s)", len(wts)))
+	fmt.Println()
+	for _, w := range wts {
+		color, state := ui.DkGray, ago(now.Sub(w.LastCommit))
+		switch {
+		case w.InUse:
+			color, state = ui.BrCyan, "in use"
+		case w.Missing:
+			color, state = ui.BrYell, "missing"
+		case w.Dirty:
+			color, state = ui.BrYell, "changes"
+		case w.Merged:
+			color, state = ui.BrGreen, "merged"
+		}
+		fmt.Printf("   %s%-20s%s %s%-28s%s %s%-10s%s %s%s%s\n",
+			ui.BrWhite, w.Project, ui.Reset,
+			ui.BrCyan, w.Branch, ui.Reset,
+			color, state, ui.Reset,
+			ui.DkGray, w.Path, ui.Reset)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runWorktreesPrune(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	wts, err := loadWorktrees(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	removed := 0
+	for _, w := range wts {
+		if !w.prunable() {
+			continue
+		}
+		name := w.Project + " " + w.Branch
+		if pruneDryRun {
+			fmt.Printf("   %s%s%s %s %s(%s)%s\n", ui.DkGray, ui.Bullet, ui.Reset, name, ui.DkGray, w.reason(), ui.Reset)
+			removed++
+			continue
+		}
+		if err := pruneWorktree(ctx, w); err != nil {
+			ui.Warn(fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		ui.Ok(fmt.Sprintf("Removed %s (%s)", name, w.reason()))
+		removed++
+	}
+
+	switch {
+	case removed == 0:
+		ui.Fin("Nothing to prune")
+	case pruneDryRun:
+		ui.Fin(fmt.Sprintf("%d worktree(s) would be removed", removed))
+	default:
+		ui.Fin(fmt.Sprintf("%d worktree(s) removed", removed))
+	}
+	return nil
+}
+
+// pruneWorktree removes w, and its branch when that is merged
+func pruneWorktree(ctx context.Context, w managedWorktree) error {
+	// Left locked by a window that has exited
+	if w.Locked {
+		if err := git.UnlockWorktree(ctx, w.Repo, w.Path); err != nil {
+			return err
+		}
+	}
+	if w.Missing {
+		if err := git.PruneWorktrees(ctx, w.Repo); err != nil {
+			return err
+		}
+	} else if err := git.RemoveWorktree(ctx, w.Repo, w.Path); err != nil {
+		return err
+	}
+	// The project's directory goes once its last worktree has
+	os.Remove(filepath.Dir(w.Path))
+
+	if w.Merged && w.Branch != "" {
+		return git.DeleteBranch(ctx, w.Repo, w.Branch)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/bcmister/cc/internal/git"
+)
+
+func TestFindAndPruneWorktrees(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	root, parent := t.TempDir(), t.TempDir()
+	repo := filepath.Join(root, "api")
+	for _, args := range [][]string{
+		{"init", "-q", repo},
+		{"-C", repo, "-c", "user.name=cc", "-c", "user.email=cc@example.com", "commit", "-q", "--allow-empty", "-m", "first"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	var wts []git.Worktree
+	for i := 0; i < 4; i++ {
+		wt, err := git.NewWorktree(ctx, repo, filepath.Join(parent, "api"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wts = append(wts, wt)
+	}
+	// One untouched and left locked by a window that has exited, one with
+	// changes, one deleted by hand and one a window is still running in
+	exited := exec.Command(os.Args[0], "-test.run=^$")
+	if err := exited.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.LockWorktree(ctx, repo, wts[0].Path, git.LockReason(exited.Process.Pid)); err != nil {
+		t.Fatal(err)
+	}
+	if err := git.LockWorktree(ctx, repo, wts[3].Path, git.LockReason(os.Getpid())); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wts[1].Path, "notes.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(wts[2].Path); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := findWorktrees(ctx, root, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 4 {
+		t.Fatalf("expected 4 worktrees, got %+v", found)
+	}
+	for _, w := range found {
+		if want := w.Branch != wts[1].Branch && w.Branch != wts[3].Branch; w.prunable() != want {
+			t.Errorf("%+v: prunable %v, want %v", w, w.prunable(), want)
+		}
+		if w.prunable() {
+			if err := pruneWorktree(ctx, w); err != nil {
+				t.Errorf("failed to prune %s: %v", w.Path, err)
+			}
+		}
+	}
+
+	found, err = findWorktrees(ctx, root, parent)
+	if err != nil || len(found) != 2 {
+		t.Fatalf("expected the worktrees with changes and in use to be left, got %+v, %v", found, err)
+	}
+	for _, w := range found {
+		if w.Branch == wts[3].Branch && !w.InUse || w.Branch == wts[1].Branch && !w.Dirty {
+			t.Errorf("unexpected %+v", w)
+		}
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -61,10 +64,91 @@ type WindowConfig struct {
 
 // Config represents the application configuration (v4)
 type Config struct {
-	Version      int             `yaml:"version"`
-	ProjectsRoot string          `yaml:"projectsRoot"`
-	Profiles     []Profile       `yaml:"profiles,omitempty"`
-	Monitors     []MonitorConfig `yaml:"monitors"`
+	Version       int             `yaml:"version"`
+	ProjectsRoot  string          `yaml:"projectsRoot"`
+	Terminal      string          `yaml:"terminal,omitempty"` // launcher name: "wt", "wt-panes" or "tmux"; empty means the default
+	Tmux          TmuxConfig      `yaml:"tmux,omitempty"`
+	Discovery     DiscoveryConfig `yaml:"discovery,omitempty"`
+	TitleTemplate string          `yaml:"titleTemplate,omitempty"` // text/template for window titles; empty means the default
+	Profiles      []Profile       `yaml:"profiles,omitempty"`
+	Monitors      []MonitorConfig `yaml:"monitors"`
+	Favorites     []string        `yaml:"favorites,omitempty"` // projects pinned to the top of the picker
+	Hidden        []string        `yaml:"hidden,omitempty"`    // projects left out of the picker
+	Overrides     []Override      `yaml:"overrides,omitempty"`
+	Clone         CloneConfig     `yaml:"clone,omitempty"`
+	Worktrees     WorktreeConfig  `yaml:"worktrees,omitempty"`
+	Loop          bool            `yaml:"loop,omitempty"` // windows go back to the picker when the tool exits instead of closing
+}
+
+// CloneConfig expands short repository references for cc clone and the
+// picker
+type CloneConfig struct {
+	// Shorthands map a prefix to what replaces it, e.g. gh: "git@github.com:"
+	// so "gh:team/api" clones over SSH. gh, gl and bb are built in.
+	Shorthands map[string]string `yaml:"shorthands,omitempty"`
+	// DefaultOrg is where a bare repository name is cloned from, e.g. "gh:team"
+	DefaultOrg string `yaml:"defaultOrg,omitempty"`
+}
+
+// WorktreeConfig says where windows get their own git worktrees
+type WorktreeConfig struct {
+	Dir string `yaml:"dir,omitempty"` // parent of every project's worktrees; defaults to ~/.cc/worktrees
+}
+
+// WorktreesDir returns where worktrees are created, one directory per
+// project
+func (c *Config) WorktreesDir() string {
+	if c.Worktrees.Dir != "" {
+		return ExpandPath(c.Worktrees.Dir)
+	}
+	return filepath.Join(filepath.Dir(DefaultConfigPath()), "worktrees")
+}
+
+// Override changes how the projects it matches are started. An override
+// with both Project and Type set matches only projects meeting both.
+type Override struct {
+	Project   string   `yaml:"project,omitempty"`   // project name; shell patterns like "api-*" allowed
+	Type      string   `yaml:"type,omitempty"`      // detected type: "go", "node", "python", "rust" or "monorepo"
+	Tool      string   `yaml:"tool,omitempty"`      // "cc" or "cx", replacing the window's tool
+	PreLaunch []string `yaml:"preLaunch,omitempty"` // commands run in the project before the tool starts
+	Worktree  bool     `yaml:"worktree,omitempty"`  // start each window in its own git worktree and branch
+}
+
+// OverrideFor merges the overrides matching project of the detected type
+// typ, in order: a later Tool wins, PreLaunch commands accumulate and any
+// Worktree turns worktrees on
+func (c *Config) OverrideFor(project, typ string) Override {
+	merged := Override{Project: project, Type: typ}
+	for _, o := range c.Overrides {
+		if o.Project != "" {
+			if ok, _ := path.Match(o.Project, project); !ok {
+				continue
+			}
+		}
+		if o.Type != "" && o.Type != typ {
+			continue
+		}
+		if o.Tool != "" {
+			merged.Tool = o.Tool
+		}
+		merged.PreLaunch = append(merged.PreLaunch, o.PreLaunch...)
+		merged.Worktree = merged.Worktree || o.Worktree
+	}
+	return merged
+}
+
+// DiscoveryConfig tunes how launched windows are found on screen.
+// Zero values fall back to the launcher's defaults.
+type DiscoveryConfig struct {
+	Timeout    time.Duration `yaml:"timeout,omitempty"`    // total wait for windows to appear, e.g. "5s"
+	Backoff    time.Duration `yaml:"backoff,omitempty"`    // first poll interval, doubled after each miss
+	MaxBackoff time.Duration `yaml:"maxBackoff,omitempty"` // cap on the poll interval
+}
+
+// TmuxConfig holds options for the tmux terminal backend
+type TmuxConfig struct {
+	Session string `yaml:"session,omitempty"` // session name, defaults to "cc"
+	Attach  bool   `yaml:"attach,omitempty"`  // add windows to an existing session instead of creating a new one
 }
 
 // HasProfiles returns true if the config has more than one profile
@@ -72,6 +156,42 @@ func (c *Config) HasProfiles() bool {
 	return len(c.Profiles) > 1
 }
 
+// SetFavorite pins or unpins project. Pinning a hidden project unhides it.
+func (c *Config) SetFavorite(project string, on bool) {
+	c.Favorites = setMember(c.Favorites, project, on)
+	if on {
+		c.Hidden = setMember(c.Hidden, project, false)
+	}
+}
+
+// SetHidden hides or unhides project. Hiding a pinned project unpins it.
+func (c *Config) SetHidden(project string, on bool) {
+	c.Hidden = setMember(c.Hidden, project, on)
+	if on {
+		c.Favorites = setMember(c.Favorites, project, false)
+	}
+}
+
+// setMember adds item to the end of list or removes it, keeping the order
+// of the rest
+func setMember(list []string, item string, on bool) []string {
+	out := make([]string, 0, len(list)+1)
+	for _, s := range list {
+		if s != item {
+			out = append(out, s)
+		} else if on {
+			return list
+		}
+	}
+	if on {
+		out = append(out, item)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
 // ExpandPath expands a leading ~ to the user's home directory
 func ExpandPath(p string) string {
 	if len(p) == 0 {
@@ -89,8 +209,17 @@ func ExpandPath(p string) string {
 
 // MonitorConfig represents configuration for a single monitor
 type MonitorConfig struct {
-	Layout  string         `yaml:"layout"`
-	Windows []WindowConfig `yaml:"windows"`
+	Nickname string         `yaml:"nickname,omitempty"` // e.g. "left", used in window titles
+	Layout   string         `yaml:"layout"`
+	Windows  []WindowConfig `yaml:"windows"`
+}
+
+// DisplayName returns the monitor's nickname, or its 1-based number at idx
+func (mc *MonitorConfig) DisplayName(idx int) string {
+	if mc.Nickname != "" {
+		return mc.Nickname
+	}
+	return strconv.Itoa(idx + 1)
 }
 
 // WindowCount returns the number of windows configured for this monitor
@@ -108,8 +237,8 @@ func (mc *MonitorConfig) ToolFor(idx int) string {
 
 // v2Config is the old format used for migration
 type v2Config struct {
-	Version      int              `yaml:"version"`
-	ProjectsRoot string           `yaml:"projectsRoot"`
+	Version      int               `yaml:"version"`
+	ProjectsRoot string            `yaml:"projectsRoot"`
 	Monitors     []v2MonitorConfig `yaml:"monitors"`
 }
 
@@ -180,9 +309,27 @@ func Load(path string) (*Config, error) {
 		cfg = upgradeToV4(cfg)
 	}
 
+	if err := validateOverrides(cfg.Overrides); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// validateOverrides catches typos that would otherwise make an override
+// silently never apply
+func validateOverrides(overrides []Override) error {
+	for i, o := range overrides {
+		if o.Tool != "" && o.Tool != "cc" && o.Tool != "cx" {
+			return fmt.Errorf("invalid config: overrides[%d]: unknown tool %q, expected cc or cx", i, o.Tool)
+		}
+		if _, err := path.Match(o.Project, ""); err != nil {
+			return fmt.Errorf("invalid config: overrides[%d]: bad project pattern %q", i, o.Project)
+		}
+	}
+	return nil
+}
+
 // migrateV2 converts a v2 config (with Windows as int) to v3 (with []WindowConfig)
 func migrateV2(data []byte) (*Config, error) {
 	var old v2Config
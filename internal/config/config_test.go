@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestMigrateV2toV3(t *testing.T) {
@@ -413,3 +416,115 @@ func TestSaveV4RoundTrip(t *testing.T) {
 		t.Errorf("expected profile 1 apiKey 'sk-test-123', got %s", loaded.Profiles[1].APIKey)
 	}
 }
+
+func TestTerminalRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	cfg := &Config{Version: 4, ProjectsRoot: "/test", Terminal: "wt"}
+	if err := Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Terminal != "wt" {
+		t.Errorf("expected terminal wt, got %q", loaded.Terminal)
+	}
+}
+
+func TestDiscoveryDurations(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	data := []byte(`version: 4
+projectsRoot: /test
+discovery:
+  timeout: 8s
+  backoff: 10ms
+monitors: []
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Discovery.Timeout != 8*time.Second || cfg.Discovery.Backoff != 10*time.Millisecond {
+		t.Errorf("unexpected discovery settings %+v", cfg.Discovery)
+	}
+
+	if err := Save(cfg, path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load after save failed: %v", err)
+	}
+	if reloaded.Discovery != cfg.Discovery {
+		t.Errorf("discovery settings changed on round trip: %+v", reloaded.Discovery)
+	}
+}
+
+func TestSetFavoriteAndHidden(t *testing.T) {
+	cfg := &Config{Favorites: []string{"api", "web"}}
+
+	cfg.SetFavorite("api", true)
+	if !reflect.DeepEqual(cfg.Favorites, []string{"api", "web"}) {
+		t.Errorf("pinning twice should keep the order, got %v", cfg.Favorites)
+	}
+
+	cfg.SetHidden("api", true)
+	if !reflect.DeepEqual(cfg.Favorites, []string{"web"}) || !reflect.DeepEqual(cfg.Hidden, []string{"api"}) {
+		t.Errorf("hiding should unpin, got favorites %v hidden %v", cfg.Favorites, cfg.Hidden)
+	}
+
+	cfg.SetFavorite("api", true)
+	if !reflect.DeepEqual(cfg.Favorites, []string{"web", "api"}) || cfg.Hidden != nil {
+		t.Errorf("pinning should unhide, got favorites %v hidden %v", cfg.Favorites, cfg.Hidden)
+	}
+
+	cfg.SetFavorite("web", false)
+	cfg.SetFavorite("api", false)
+	if cfg.Favorites != nil {
+		t.Errorf("expected no favorites, got %v", cfg.Favorites)
+	}
+}
+
+func TestOverrideFor(t *testing.T) {
+	cfg := &Config{Overrides: []Override{
+		{Type: "node", PreLaunch: []string{"pnpm install"}},
+		{Project: "api-*", Tool: "cx", Worktree: true},
+		{Project: "api-web", Type: "node", Tool: "cc", PreLaunch: []string{"make env"}},
+	}}
+
+	o := cfg.OverrideFor("api-web", "node")
+	if o.Tool != "cc" || !reflect.DeepEqual(o.PreLaunch, []string{"pnpm install", "make env"}) || !o.Worktree {
+		t.Errorf("expected later overrides to win and commands to accumulate, got %+v", o)
+	}
+	if o := cfg.OverrideFor("api-core", "go"); o.Tool != "cx" || o.PreLaunch != nil {
+		t.Errorf("expected only the name pattern to match, got %+v", o)
+	}
+	if o := cfg.OverrideFor("web", ""); o.Tool != "" || o.PreLaunch != nil || o.Worktree {
+		t.Errorf("expected no override, got %+v", o)
+	}
+}
+
+func TestLoadRejectsBadOverrides(t *testing.T) {
+	for _, body := range []string{
+		"version: 4\noverrides:\n  - type: go\n    tool: vim\n",
+		"version: 4\noverrides:\n  - project: \"[api\"\n",
+	} {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "overrides[0]") {
+			t.Errorf("expected an error naming the override, got %v", err)
+		}
+	}
+}
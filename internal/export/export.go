@@ -0,0 +1,69 @@
+// Package export turns the computed window layout into session files for
+// terminals that manage their own panes: Zellij, WezTerm and kitty.
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bcmister/cc/internal/window"
+)
+
+// Format is one supported export target
+type Format struct {
+	Name   string
+	Ext    string                             // file extension for the generated file
+	Render func(groups []window.Group) string // builds the file contents
+	// LaunchArgv returns the command that starts the terminal with the generated file
+	LaunchArgv func(path string) []string
+	// Foreground is true when the terminal runs inside the current one
+	// rather than opening its own window
+	Foreground bool
+}
+
+var formats = map[string]Format{
+	"zellij": {
+		Name:       "zellij",
+		Ext:        ".kdl",
+		Render:     Zellij,
+		LaunchArgv: func(path string) []string { return []string{"zellij", "--layout", path} },
+		Foreground: true,
+	},
+	"wezterm": {
+		Name:       "wezterm",
+		Ext:        ".lua",
+		Render:     WezTerm,
+		LaunchArgv: func(path string) []string { return []string{"wezterm", "--config-file", path, "start"} },
+	},
+	"kitty": {
+		Name:       "kitty",
+		Ext:        ".session",
+		Render:     Kitty,
+		LaunchArgv: func(path string) []string { return []string{"kitty", "--session", path} },
+	},
+}
+
+// Lookup returns the format registered under name
+func Lookup(name string) (Format, error) {
+	f, ok := formats[strings.ToLower(name)]
+	if !ok {
+		return Format{}, fmt.Errorf("unknown export format %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return f, nil
+}
+
+// Names returns the supported format names in sorted order
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tabName names the tab or window that holds a monitor group
+func tabName(g window.Group) string {
+	return fmt.Sprintf("monitor-%d", g.Monitor+1)
+}
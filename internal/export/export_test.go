@@ -0,0 +1,91 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bcmister/cc/internal/window"
+)
+
+func testGroups() []window.Group {
+	cfg := func(title string, x int) window.LaunchConfig {
+		return window.LaunchConfig{Title: title, WorkingDir: `C:\dev`, X: x, Command: "claude", Label: "cc"}
+	}
+	return []window.Group{
+		{Monitor: 0, Layout: "full", Configs: []window.LaunchConfig{cfg("cc-1-1", 0)}},
+		{Monitor: 1, Layout: "grid", Configs: []window.LaunchConfig{
+			cfg("cc-2-1", 1536), cfg("cc-2-2", 2496), cfg("cx-2-3", 1536),
+		}},
+	}
+}
+
+func TestZellij(t *testing.T) {
+	out := Zellij(testGroups())
+
+	for _, want := range []string{
+		`tab name="monitor-1" {`,
+		`tab name="monitor-2" split_direction="vertical" {`,
+		`pane split_direction="horizontal" {`,
+		`pane name="cx-2-3" cwd="C:\\dev"`,
+		`args "pick" "--dir" "C:\\dev"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in layout:\n%s", want, out)
+		}
+	}
+
+	// Grid of three: first column holds cells 1 and 3, so cx-2-3 comes before cc-2-2
+	if strings.Index(out, `"cx-2-3"`) > strings.Index(out, `"cc-2-2"`) {
+		t.Errorf("expected grid to be written column by column:\n%s", out)
+	}
+	if strings.Count(out, "{") != strings.Count(out, "}") {
+		t.Errorf("unbalanced braces in layout:\n%s", out)
+	}
+}
+
+func TestWezTerm(t *testing.T) {
+	out := WezTerm(testGroups())
+
+	if n := strings.Count(out, "mux.spawn_window"); n != 2 {
+		t.Errorf("expected 2 windows, got %d", n)
+	}
+	for _, want := range []string{
+		"position = { x = 1536, y = 0, origin = 'ScreenCoordinateSystem' }",
+		"local p1 = p0:split { direction = 'Right', size = 0.5, cwd = 'C:\\\\dev'",
+		"local p2 = p0:split { direction = 'Bottom', size = 0.5",
+		"return {}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in config:\n%s", want, out)
+		}
+	}
+}
+
+func TestKitty(t *testing.T) {
+	out := Kitty(testGroups())
+
+	if n := strings.Count(out, "new_os_window"); n != 1 {
+		t.Errorf("expected 1 extra OS window, got %d", n)
+	}
+	for _, want := range []string{
+		"new_tab monitor-1\nlayout stack\n",
+		"new_tab monitor-2\nlayout grid\n",
+		`launch --title cx-2-3 --cwd 'C:\dev' `,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in session:\n%s", want, out)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range Names() {
+		f, err := Lookup(name)
+		if err != nil || f.Name != name {
+			t.Errorf("Lookup(%q) = %+v, %v", name, f, err)
+		}
+	}
+	if _, err := Lookup("alacritty"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
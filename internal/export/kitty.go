@@ -0,0 +1,61 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcmister/cc/internal/window"
+)
+
+// kittyLayouts maps config layout names onto kitty's layouts. kitty names
+// them by how windows are laid out, so side-by-side columns are "horizontal".
+var kittyLayouts = map[string]string{
+	"full":       "stack",
+	"grid":       "grid",
+	"vertical":   "horizontal",
+	"horizontal": "vertical",
+}
+
+// Kitty renders a session file with one OS window per monitor, each holding
+// a single tab laid out with the matching kitty layout.
+func Kitty(groups []window.Group) string {
+	var b strings.Builder
+	b.WriteString("# Generated by cc export. Start with: kitty --session <this file>\n")
+
+	first := true
+	for _, g := range groups {
+		if len(g.Configs) == 0 {
+			continue
+		}
+		b.WriteString("\n")
+		if !first {
+			b.WriteString("new_os_window\n")
+		}
+		first = false
+
+		layout, ok := kittyLayouts[g.Layout]
+		if !ok {
+			layout = "grid"
+		}
+		fmt.Fprintf(&b, "new_tab %s\n", tabName(g))
+		fmt.Fprintf(&b, "layout %s\n", layout)
+		for _, cfg := range g.Configs {
+			args := append([]string{"launch", "--title", cfg.Title, "--cwd", cfg.WorkingDir}, window.SpecFor(cfg).Argv...)
+			b.WriteString(shellJoin(args) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// shellJoin joins args POSIX-shell style, quoting only where needed
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && !strings.ContainsAny(a, " \t\n'\"\\$`;&|<>()*?[]#~") {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
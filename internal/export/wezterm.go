@@ -0,0 +1,74 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcmister/cc/internal/window"
+)
+
+// WezTermWorkspace is the workspace the generated windows are spawned into
+const WezTermWorkspace = "cc"
+
+// WezTerm renders a Lua config whose gui-startup handler spawns one
+// maximized window per monitor and splits it into the monitor's panes.
+// It can be used directly with --config-file or pasted into wezterm.lua.
+func WezTerm(groups []window.Group) string {
+	var b strings.Builder
+	b.WriteString("-- Generated by cc export. Start with: wezterm --config-file <this file> start\n")
+	b.WriteString("-- or copy the gui-startup handler into your own wezterm.lua.\n")
+	b.WriteString("local wezterm = require 'wezterm'\n")
+	b.WriteString("local mux = wezterm.mux\n\n")
+	b.WriteString("wezterm.on('gui-startup', function()\n")
+
+	for _, g := range groups {
+		if len(g.Configs) == 0 {
+			continue
+		}
+		first := g.Configs[0]
+		fmt.Fprintf(&b, "  -- Monitor %d\n", g.Monitor+1)
+		b.WriteString("  do\n")
+		b.WriteString("    local tab, p0, win = mux.spawn_window {\n")
+		fmt.Fprintf(&b, "      workspace = %s,\n", luaString(WezTermWorkspace))
+		fmt.Fprintf(&b, "      cwd = %s,\n", luaString(first.WorkingDir))
+		fmt.Fprintf(&b, "      args = %s,\n", luaList(window.SpecFor(first).Argv))
+		fmt.Fprintf(&b, "      position = { x = %d, y = %d, origin = 'ScreenCoordinateSystem' },\n", first.X, first.Y)
+		b.WriteString("    }\n")
+		fmt.Fprintf(&b, "    tab:set_title(%s)\n", luaString(tabName(g)))
+		b.WriteString("    win:gui_window():maximize()\n")
+
+		for i, s := range window.SplitPlan(len(g.Configs), g.Layout) {
+			cfg := g.Configs[s.Cell]
+			fmt.Fprintf(&b, "    local p%d = p%d:split { direction = %s, size = %.4g, cwd = %s, args = %s }\n",
+				i+1, s.Parent, luaString(wezTermDirection(s.Direction)), s.Size,
+				luaString(cfg.WorkingDir), luaList(window.SpecFor(cfg).Argv))
+		}
+		b.WriteString("  end\n")
+	}
+
+	fmt.Fprintf(&b, "  mux.set_active_workspace(%s)\n", luaString(WezTermWorkspace))
+	b.WriteString("end)\n\n")
+	b.WriteString("return {}\n")
+	return b.String()
+}
+
+func wezTermDirection(d window.Direction) string {
+	if d == window.SplitDown {
+		return "Bottom"
+	}
+	return "Right"
+}
+
+// luaString quotes s as a Lua string literal
+func luaString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`)
+	return "'" + r.Replace(s) + "'"
+}
+
+func luaList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, s := range items {
+		quoted[i] = luaString(s)
+	}
+	return "{ " + strings.Join(quoted, ", ") + " }"
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bcmister/cc/internal/window"
+)
+
+// Zellij renders a KDL layout with one tab per monitor. Grids are written
+// as columns of stacked panes, matching window.SplitPlan.
+func Zellij(groups []window.Group) string {
+	var b strings.Builder
+	b.WriteString("// Generated by cc export. Start with: zellij --layout <this file>\n")
+	b.WriteString("layout {\n")
+	for _, g := range groups {
+		if len(g.Configs) == 0 {
+			continue
+		}
+		count := len(g.Configs)
+		switch {
+		case count == 1 || g.Layout == "full":
+			fmt.Fprintf(&b, "    tab name=%s {\n", kdlString(tabName(g)))
+			zellijPane(&b, 2, g.Configs[0])
+		case g.Layout == "vertical" || g.Layout == "horizontal":
+			fmt.Fprintf(&b, "    tab name=%s split_direction=%s {\n", kdlString(tabName(g)), kdlString(zellijDirection(g.Layout)))
+			for _, cfg := range g.Configs {
+				zellijPane(&b, 2, cfg)
+			}
+		default:
+			cols, _ := window.GridSize(count)
+			fmt.Fprintf(&b, "    tab name=%s split_direction=\"vertical\" {\n", kdlString(tabName(g)))
+			for c := 0; c < cols && c < count; c++ {
+				b.WriteString("        pane split_direction=\"horizontal\" {\n")
+				for i := c; i < count; i += cols {
+					zellijPane(&b, 3, g.Configs[i])
+				}
+				b.WriteString("        }\n")
+			}
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// zellijDirection maps a layout onto zellij's split direction, which names
+// the orientation of the dividing line
+func zellijDirection(layout string) string {
+	if layout == "horizontal" {
+		return "horizontal"
+	}
+	return "vertical"
+}
+
+func zellijPane(b *strings.Builder, depth int, cfg window.LaunchConfig) {
+	indent := strings.Repeat("    ", depth)
+	argv := window.SpecFor(cfg).Argv
+	fmt.Fprintf(b, "%spane name=%s cwd=%s command=%s {\n", indent, kdlString(cfg.Title), kdlString(cfg.WorkingDir), kdlString(argv[0]))
+	if len(argv) > 1 {
+		quoted := make([]string, len(argv)-1)
+		for i, a := range argv[1:] {
+			quoted[i] = kdlString(a)
+		}
+		fmt.Fprintf(b, "%s    args %s\n", indent, strings.Join(quoted, " "))
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// kdlString quotes s as a KDL string literal
+func kdlString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
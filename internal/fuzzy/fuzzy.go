@@ -0,0 +1,180 @@
+// Package fuzzy matches a typed pattern against candidate strings the way
+// fzf does: the pattern's characters must appear in order, and matches score
+// higher when they are consecutive or start words and path segments.
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+)
+
+// Scoring weights, after fzf's
+const (
+	scoreMatch        = 16
+	gapStart          = -3
+	gapExtension      = -1
+	bonusPath         = 10 // after a path separator
+	bonusBoundary     = 8  // at the start or after a space, dash, underscore or dot
+	bonusCamel        = 7  // lower-to-upper or letter-to-digit transition
+	bonusConsecutive  = 6  // directly after the previous matched character
+	firstCharMultiple = 2  // the first pattern character's bonus counts double
+)
+
+// Match is one candidate that matched a pattern
+type Match struct {
+	Index     int   // index into the candidates
+	Score     int   // higher is better
+	Positions []int // rune offsets of the matched characters
+}
+
+// Find matches pattern against every candidate and returns the matches best
+// first. Equal scores are ordered by less, which compares candidate
+// indexes, or by index when less is nil. An empty pattern matches everything
+// with a score of zero.
+func Find(pattern string, candidates []string, less func(i, j int) bool) []Match {
+	p := lower([]rune(pattern))
+
+	matches := make([]Match, 0, len(candidates))
+	for i, c := range candidates {
+		if score, pos, ok := match(p, []rune(c)); ok {
+			matches = append(matches, Match{Index: i, Score: score, Positions: pos})
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		if less != nil {
+			return less(matches[a].Index, matches[b].Index)
+		}
+		return matches[a].Index < matches[b].Index
+	})
+	return matches
+}
+
+// Score matches pattern against one candidate, ignoring case
+func Score(pattern, candidate string) (score int, positions []int, ok bool) {
+	return match(lower([]rune(pattern)), []rune(candidate))
+}
+
+// match finds the best-scoring alignment of p in c. h[i][j] is the best
+// score for p[:i+1] with p[i] matched at c[j]; a gap before j is tracked
+// incrementally so each row costs O(len(c)). p must already be lower case.
+func match(p, c []rune) (int, []int, bool) {
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	if len(p) > len(c) {
+		return 0, nil, false
+	}
+	folded := lower(c)
+	if !subsequence(p, folded) {
+		return 0, nil, false
+	}
+
+	n, m := len(c), len(p)
+	bonus := make([]int, n)
+	for j := range c {
+		bonus[j] = bonusAt(c, j)
+	}
+
+	const none = -1 << 30
+	h := make([][]int, m)
+	from := make([][]int, m) // position of p[i-1] in the best alignment ending at j
+	for i := range h {
+		h[i] = make([]int, n)
+		from[i] = make([]int, n)
+	}
+
+	for j := 0; j < n; j++ {
+		h[0][j] = none
+		if folded[j] == p[0] {
+			h[0][j] = scoreMatch + bonus[j]*firstCharMultiple
+		}
+	}
+
+	for i := 1; i < m; i++ {
+		carry, carryFrom := none, -1 // best gapped predecessor for the current j
+		for j := 0; j < n; j++ {
+			h[i][j] = none
+			if j >= 2 {
+				// Extend the gap, or start one from p[i-1] matched at j-2
+				carry += gapExtension
+				if s := h[i-1][j-2]; s > none && s+gapStart > carry {
+					carry, carryFrom = s+gapStart, j-2
+				}
+			}
+			if folded[j] != p[i] || j == 0 {
+				continue
+			}
+
+			best, bestFrom := none, -1
+			if s := h[i-1][j-1]; s > none {
+				best, bestFrom = s+bonusConsecutive, j-1
+			}
+			if carryFrom >= 0 && carry > best {
+				best, bestFrom = carry, carryFrom
+			}
+			if bestFrom < 0 {
+				continue
+			}
+			h[i][j] = best + scoreMatch + bonus[j]
+			from[i][j] = bestFrom
+		}
+	}
+
+	end, score := -1, none
+	for j := 0; j < n; j++ {
+		if h[m-1][j] > score {
+			end, score = j, h[m-1][j]
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, m)
+	for i, j := m-1, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return score, positions, true
+}
+
+// bonusAt rewards characters that start a word or path segment
+func bonusAt(c []rune, j int) int {
+	if j == 0 {
+		return bonusBoundary
+	}
+	prev, cur := c[j-1], c[j]
+	switch {
+	case prev == '/' || prev == '\\':
+		return bonusPath
+	case prev == ' ' || prev == '-' || prev == '_' || prev == '.':
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return bonusCamel
+	case unicode.IsLetter(prev) && unicode.IsDigit(cur):
+		return bonusCamel
+	}
+	return 0
+}
+
+func subsequence(p, c []rune) bool {
+	i := 0
+	for _, r := range c {
+		if i < len(p) && r == p[i] {
+			i++
+		}
+	}
+	return i == len(p)
+}
+
+func lower(rs []rune) []rune {
+	out := make([]rune, len(rs))
+	for i, r := range rs {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
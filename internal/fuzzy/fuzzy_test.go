@@ -0,0 +1,106 @@
+package fuzzy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func names(candidates []string, matches []Match) []string {
+	var out []string
+	for _, m := range matches {
+		out = append(out, candidates[m.Index])
+	}
+	return out
+}
+
+func TestScoreRequiresOrderedSubsequence(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, candidate string
+		ok                 bool
+	}{
+		{"", "anything", true},
+		{"aps", "api-server", true},
+		{"APS", "api-server", true},
+		{"spa", "api-server", false},
+		{"api-server-x", "api-server", false},
+	} {
+		if _, _, ok := Score(tc.pattern, tc.candidate); ok != tc.ok {
+			t.Errorf("Score(%q, %q) ok = %v, want %v", tc.pattern, tc.candidate, ok, tc.ok)
+		}
+	}
+}
+
+func TestScorePositions(t *testing.T) {
+	// The boundary alignment beats the earliest one
+	_, pos, _ := Score("as", "abc-api-server")
+	if want := []int{4, 8}; !reflect.DeepEqual(pos, want) {
+		t.Errorf("got positions %v, want %v", pos, want)
+	}
+	_, pos, _ = Score("cc", "go/cmd/cc")
+	if want := []int{7, 8}; !reflect.DeepEqual(pos, want) {
+		t.Errorf("got positions %v, want %v", pos, want)
+	}
+}
+
+func TestFindRanking(t *testing.T) {
+	for _, tc := range []struct {
+		pattern    string
+		candidates []string
+		want       []string
+	}{
+		// Consecutive characters beat scattered ones
+		{"web", []string{"wide-embed", "webapp"}, []string{"webapp", "wide-embed"}},
+		// Word starts beat letters mid-word
+		{"as", []string{"canvas", "api-server"}, []string{"api-server", "canvas"}},
+		// Camel case counts as a word start
+		{"ms", []string{"amiss", "myService"}, []string{"myService", "amiss"}},
+		// A path segment beats a letter mid-word
+		{"cli", []string{"uncling", "tools/cli"}, []string{"tools/cli", "uncling"}},
+	} {
+		got := names(tc.candidates, Find(tc.pattern, tc.candidates, nil))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Find(%q) = %v, want %v", tc.pattern, got, tc.want)
+		}
+	}
+}
+
+func TestFindTieBreak(t *testing.T) {
+	candidates := []string{"api-old", "api-new", "web"}
+	if got := names(candidates, Find("api", candidates, nil)); !reflect.DeepEqual(got, []string{"api-old", "api-new"}) {
+		t.Errorf("expected ties in candidate order, got %v", got)
+	}
+
+	recent := map[int]int{0: 1, 1: 2}
+	got := names(candidates, Find("api", candidates, func(i, j int) bool { return recent[i] > recent[j] }))
+	if !reflect.DeepEqual(got, []string{"api-new", "api-old"}) {
+		t.Errorf("expected ties broken by less, got %v", got)
+	}
+
+	if got := Find("", candidates, nil); len(got) != 3 || got[0].Score != 0 {
+		t.Errorf("expected an empty pattern to match everything, got %v", got)
+	}
+}
+
+func benchCandidates() []string {
+	words := []string{"api", "web", "server", "client", "infra", "tools", "docs", "mobile", "billing", "auth"}
+	var out []string
+	for i := 0; i < 200; i++ {
+		out = append(out, fmt.Sprintf("%s-%s-%d", words[i%len(words)], words[(i/len(words))%len(words)], i))
+	}
+	return out
+}
+
+func BenchmarkScore(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Score("apisrv", "api-gateway-server-v2")
+	}
+}
+
+func BenchmarkFind(b *testing.B) {
+	candidates := benchCandidates()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Find("bilcli", candidates, nil)
+	}
+}
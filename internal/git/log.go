@@ -0,0 +1,21 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Log returns the last n commits in dir, newest first, as
+// "abc1234 subject (3 days ago)"
+func Log(ctx context.Context, dir string, n int) ([]string, error) {
+	out, err := run(ctx, dir, "log", fmt.Sprintf("-%d", n), "--format=%h %s (%cr)")
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(string(out), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
@@ -0,0 +1,79 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultShorthands expand references like "gh:team/repo". Each value is
+// prepended to the path after the colon.
+var DefaultShorthands = map[string]string{
+	"gh": "https://github.com/",
+	"gl": "https://gitlab.com/",
+	"bb": "https://bitbucket.org/",
+}
+
+// scpLike matches addresses such as git@github.com:team/repo.git
+var scpLike = regexp.MustCompile(`^[\w.-]+@[\w.-]+:.+`)
+
+// Remote resolves ref to something git clone accepts. ref may be a URL, an
+// scp-style address, a local repository (bare or not), "prefix:path" using
+// shorthands on top of DefaultShorthands, or a plain repository name cloned
+// from defaultOrg, e.g. "gh:team". ok is false when ref is none of these.
+func Remote(ref string, shorthands map[string]string, defaultOrg string) (remote string, ok bool) {
+	switch {
+	case ref == "":
+		return "", false
+	case strings.Contains(ref, "://"):
+		return ref, true
+	case isLocalRepo(ref):
+		abs, err := filepath.Abs(ref)
+		if err != nil {
+			return ref, true
+		}
+		return abs, true
+	case scpLike.MatchString(ref):
+		return ref, true
+	}
+
+	if prefix, path, found := strings.Cut(ref, ":"); found && path != "" {
+		if base, ok := shorthands[prefix]; ok {
+			return base + path, true
+		}
+		if base, ok := DefaultShorthands[prefix]; ok {
+			return base + path, true
+		}
+		return "", false
+	}
+
+	if defaultOrg != "" && !strings.ContainsAny(ref, `:/\`) {
+		return Remote(defaultOrg+"/"+ref, shorthands, "")
+	}
+	return "", false
+}
+
+// RepoName returns the directory git clone would create for remote
+func RepoName(remote string) string {
+	name := strings.TrimRight(strings.ReplaceAll(remote, `\`, "/"), "/")
+	name = strings.TrimSuffix(name, "/.git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, ".git")
+}
+
+// isLocalRepo reports whether path is a repository on disk, either a
+// working tree or a bare repository
+func isLocalRepo(path string) bool {
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return false
+	}
+	if IsRepo(path) {
+		return true
+	}
+	_, head := os.Stat(filepath.Join(path, "HEAD"))
+	_, objects := os.Stat(filepath.Join(path, "objects"))
+	return head == nil && objects == nil
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemote(t *testing.T) {
+	short := map[string]string{"gh": "git@github.com:", "corp": "https://git.corp.example/"}
+	for _, tc := range []struct {
+		ref, org, want string
+		ok             bool
+	}{
+		{"https://github.com/team/api.git", "", "https://github.com/team/api.git", true},
+		{"ssh://git@host:2222/team/api", "", "ssh://git@host:2222/team/api", true},
+		{"git@github.com:team/api.git", "", "git@github.com:team/api.git", true},
+		{"gh:team/api", "", "git@github.com:team/api", true},
+		{"gl:team/api", "", "https://gitlab.com/team/api", true},
+		{"corp:infra/deploy", "", "https://git.corp.example/infra/deploy", true},
+		{"api", "corp:team", "https://git.corp.example/team/api", true},
+		{"api", "", "", false},
+		{"nope:team/api", "", "", false},
+		{"team/api", "corp:team", "", false},
+	} {
+		got, ok := Remote(tc.ref, short, tc.org)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("Remote(%q, %q) = %q, %v; want %q, %v", tc.ref, tc.org, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestRemoteLocalBareRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	bare := filepath.Join(t.TempDir(), "api.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", bare).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v\n%s", err, out)
+	}
+
+	if got, ok := Remote(bare, nil, ""); !ok || got != bare {
+		t.Errorf("expected the bare repository to be accepted, got %q, %v", got, ok)
+	}
+	if _, ok := Remote(t.TempDir(), nil, ""); ok {
+		t.Errorf("expected a plain directory to be rejected")
+	}
+}
+
+func TestClone(t *testing.T) {
+	src := t.TempDir()
+	gitRepo(t, src)
+	bare := filepath.Join(t.TempDir(), "api.git")
+	if out, err := exec.Command("git", "clone", "-q", "--bare", src, bare).CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare: %v\n%s", err, out)
+	}
+
+	dir := filepath.Join(t.TempDir(), RepoName(bare))
+	if err := Clone(context.Background(), bare, dir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil || filepath.Base(dir) != "api" {
+		t.Errorf("expected a checkout in api: %v", err)
+	}
+}
+
+func TestCloneRemoteStartingWithDash(t *testing.T) {
+	src := t.TempDir()
+	gitRepo(t, src)
+	parent := t.TempDir()
+	if out, err := exec.Command("git", "clone", "-q", "--bare", "--", src, filepath.Join(parent, "-api.git")).CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare: %v\n%s", err, out)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(parent); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	dir := filepath.Join(t.TempDir(), "api")
+	if err := Clone(context.Background(), "-api.git", dir, nil); err != nil {
+		t.Fatalf("expected -api.git to be cloned as a remote: %v", err)
+	}
+}
+
+func TestRepoName(t *testing.T) {
+	for remote, want := range map[string]string{
+		"https://github.com/team/api.git": "api",
+		"git@github.com:team/api.git":     "api",
+		"git@host:api":                    "api",
+		"/srv/git/api.git/":               "api",
+		"/srv/git/api/.git":               "api",
+		`C:\repos\api.git`:                "api",
+	} {
+		if got := RepoName(remote); got != want {
+			t.Errorf("RepoName(%q) = %q, want %q", remote, got, want)
+		}
+	}
+}
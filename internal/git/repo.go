@@ -0,0 +1,26 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// Init creates an empty repository in dir
+func Init(ctx context.Context, dir string) error {
+	_, err := run(ctx, dir, "init", "-q")
+	return err
+}
+
+// Clone clones remote into dir, writing git's progress to progress
+func Clone(ctx context.Context, remote, dir string, progress io.Writer) error {
+	// A remote starting with "-" is still a remote, not an option
+	cmd := exec.CommandContext(ctx, "git", "clone", "--progress", "--", remote, dir)
+	cmd.Stdout = progress
+	cmd.Stderr = progress
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git clone failed: %w", err)
+	}
+	return nil
+}
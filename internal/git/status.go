@@ -0,0 +1,133 @@
+// Package git reads repository state by running the git command line.
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status is the state of one working tree
+type Status struct {
+	Repo       bool   // false when the directory is not a git repository
+	Branch     string // "" when HEAD is detached
+	Dirty      bool   // modified, staged or untracked files
+	Ahead      int    // commits not on the upstream
+	Behind     int    // upstream commits not merged
+	LastCommit time.Time
+}
+
+// IsRepo reports whether dir is the top of a git working tree
+func IsRepo(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// Read returns the status of the working tree in dir. A directory that is
+// not a repository returns a zero Status and no error.
+func Read(ctx context.Context, dir string) (Status, error) {
+	if !IsRepo(dir) {
+		return Status{}, nil
+	}
+
+	out, err := run(ctx, dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return Status{}, err
+	}
+	s := parseStatus(out)
+
+	// An empty repository has no commit to date
+	if out, err := run(ctx, dir, "log", "-1", "--format=%ct"); err == nil {
+		if sec, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+			s.LastCommit = time.Unix(sec, 0)
+		}
+	}
+	return s, ctx.Err()
+}
+
+// parseStatus reads the output of git status --porcelain=v2 --branch
+func parseStatus(out []byte) Status {
+	s := Status{Repo: true}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				s.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.ab "):
+			fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &s.Ahead, &s.Behind)
+		case strings.HasPrefix(line, "#"):
+		case line != "":
+			s.Dirty = true
+		}
+	}
+	return s
+}
+
+// ProjectStatus is the status of one project, as sent by Stream
+type ProjectStatus struct {
+	Project string
+	Status  Status
+}
+
+// Stream reads the status of each project in root, a few at a time,
+// sending each as soon as it is read so one slow repository never holds up
+// the rest. Projects that fail to read are left out. The channel is closed
+// once every project is read, or when ctx is done, abandoning the rest.
+func Stream(ctx context.Context, root string, projects []string, workers int) <-chan ProjectStatus {
+	jobs := make(chan string)
+	results := make(chan ProjectStatus, len(projects))
+	var wg sync.WaitGroup
+	for i := 0; i < max(1, workers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				if s, err := Read(ctx, filepath.Join(root, p)); err == nil {
+					results <- ProjectStatus{p, s}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, p := range projects {
+			select {
+			case jobs <- p:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// run runs git in dir and returns its standard output
+func run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s failed: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return out, nil
+}
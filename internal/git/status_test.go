@@ -0,0 +1,90 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseStatus(t *testing.T) {
+	out := []byte(`# branch.oid 1234abcd
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +2 -1
+1 .M N... 100644 100644 100644 aaaa bbbb README.md
+`)
+	s := parseStatus(out)
+	if !s.Repo || s.Branch != "main" || !s.Dirty || s.Ahead != 2 || s.Behind != 1 {
+		t.Errorf("unexpected status %+v", s)
+	}
+
+	s = parseStatus([]byte("# branch.oid 1234abcd\n# branch.head (detached)\n"))
+	if s.Branch != "" || s.Dirty {
+		t.Errorf("expected a clean detached HEAD, got %+v", s)
+	}
+}
+
+// gitRepo creates a repository with one commit in dir, skipping the test
+// when git is not installed
+func gitRepo(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=cc", "-c", "user.email=cc@example.com", "commit", "-q", "--allow-empty", "-m", "first"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	root := t.TempDir()
+	for _, p := range []string{"api", "web", "notes"} {
+		if err := os.Mkdir(filepath.Join(root, p), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitRepo(t, filepath.Join(root, "api"))
+	gitRepo(t, filepath.Join(root, "web"))
+	if err := os.WriteFile(filepath.Join(root, "web", "new.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]Status{}
+	for ps := range Stream(context.Background(), root, []string{"api", "web", "notes"}, 2) {
+		got[ps.Project] = ps.Status
+	}
+	if s := got["api"]; !s.Repo || s.Dirty || s.Branch != "main" || time.Since(s.LastCommit) > time.Minute {
+		t.Errorf("unexpected api status %+v", s)
+	}
+	if s := got["web"]; !s.Dirty {
+		t.Errorf("expected web to be dirty, got %+v", s)
+	}
+	if s, ok := got["notes"]; !ok || s.Repo {
+		t.Errorf("expected notes to be reported as not a repository, got %+v", s)
+	}
+}
+
+func TestStreamStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	n := 0
+	for range Stream(ctx, t.TempDir(), []string{"a", "b", "c"}, 1) {
+		n++
+	}
+	if n > 3 {
+		t.Errorf("unexpected statuses, got %d", n)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected the stream to close once the context is done")
+	}
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BranchPrefix starts the name of every branch made for a worktree
+const BranchPrefix = "cc/"
+
+// Worktree is one working tree of a repository
+type Worktree struct {
+	Path    string
+	Branch  string // "" when HEAD is detached
+	Head    string // commit checked out
+	Missing bool   // the directory is gone but git still tracks it
+	Locked  bool   // kept from being removed or pruned
+	Reason  string // why the tree is locked, if given
+}
+
+// Worktrees lists the working trees of repo, the main one first
+func Worktrees(ctx context.Context, repo string) ([]Worktree, error) {
+	out, err := run(ctx, repo, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(out), nil
+}
+
+// parseWorktrees reads the output of git worktree list --porcelain
+func parseWorktrees(out []byte) []Worktree {
+	var wts []Worktree
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		key, value, _ := strings.Cut(sc.Text(), " ")
+		if key == "worktree" {
+			wts = append(wts, Worktree{Path: filepath.FromSlash(value)})
+			continue
+		}
+		if len(wts) == 0 {
+			continue
+		}
+		wt := &wts[len(wts)-1]
+		switch key {
+		case "HEAD":
+			wt.Head = value
+		case "branch":
+			wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "prunable":
+			wt.Missing = true
+		case "locked":
+			wt.Locked, wt.Reason = true, value
+		}
+	}
+	return wts
+}
+
+// NewWorktree adds a working tree for repo in the first free numbered
+// directory of parent, on a new branch named after it and started from
+// repo's HEAD. Windows racing for the same number each get their own.
+func NewWorktree(ctx context.Context, repo, parent string) (Worktree, error) {
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return Worktree{}, fmt.Errorf("failed to create %s: %w", parent, err)
+	}
+	taken := func(dir, branch string) bool {
+		_, err := os.Stat(dir)
+		return err == nil || branchExists(ctx, repo, branch)
+	}
+
+	for n := 1; ; n++ {
+		dir := filepath.Join(parent, strconv.Itoa(n))
+		branch := BranchPrefix + filepath.Base(parent) + "-" + strconv.Itoa(n)
+		if taken(dir, branch) {
+			continue
+		}
+		_, err := run(ctx, repo, "worktree", "add", "-q", "-b", branch, dir)
+		if err == nil {
+			return Worktree{Path: dir, Branch: branch}, nil
+		}
+		if !taken(dir, branch) || ctx.Err() != nil {
+			return Worktree{}, err
+		}
+	}
+}
+
+// LockWorktree keeps the working tree at dir from being removed or pruned,
+// recording reason
+func LockWorktree(ctx context.Context, repo, dir, reason string) error {
+	_, err := run(ctx, repo, "worktree", "lock", "--reason", reason, dir)
+	return err
+}
+
+// UnlockWorktree lets the working tree at dir be removed again
+func UnlockWorktree(ctx context.Context, repo, dir string) error {
+	_, err := run(ctx, repo, "worktree", "unlock", dir)
+	return err
+}
+
+// LockReason is the reason a window locks its worktree with while process
+// pid runs in it
+func LockReason(pid int) string {
+	return fmt.Sprintf("in use by cc (pid %d)", pid)
+}
+
+// LockHolder returns the process a worktree was locked for by LockReason,
+// or 0 when something else locked it
+func LockHolder(reason string) int {
+	var pid int
+	if _, err := fmt.Sscanf(reason, "in use by cc (pid %d)", &pid); err != nil {
+		return 0
+	}
+	return pid
+}
+
+// RemoveWorktree removes the working tree at dir from repo. git refuses
+// when the tree has changes, so nothing uncommitted is lost.
+func RemoveWorktree(ctx context.Context, repo, dir string) error {
+	_, err := run(ctx, repo, "worktree", "remove", dir)
+	return err
+}
+
+// PruneWorktrees forgets the working trees of repo whose directories are
+// gone
+func PruneWorktrees(ctx context.Context, repo string) error {
+	_, err := run(ctx, repo, "worktree", "prune")
+	return err
+}
+
+// Merged reports whether branch is merged into the branch checked out in
+// repo
+func Merged(ctx context.Context, repo, branch string) (bool, error) {
+	out, err := run(ctx, repo, "branch", "--merged", "HEAD", "--list", branch)
+	if err != nil {
+		return false, err
+	}
+	return len(bytes.TrimSpace(out)) > 0, nil
+}
+
+// DeleteBranch deletes branch from repo if it is merged
+func DeleteBranch(ctx context.Context, repo, branch string) error {
+	_, err := run(ctx, repo, "branch", "-q", "-d", branch)
+	return err
+}
+
+func branchExists(ctx context.Context, repo, branch string) bool {
+	_, err := run(ctx, repo, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil
+}
@@ -0,0 +1,119 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	out := []byte(`worktree /src/api
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /wt/api/1
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/cc/api-1
+
+worktree /wt/api/2
+HEAD 3333333333333333333333333333333333333333
+detached
+prunable gitdir file points to non-existent location
+
+worktree /wt/api/3
+HEAD 4444444444444444444444444444444444444444
+branch refs/heads/cc/api-3
+locked in use by cc (pid 42)
+`)
+	got := parseWorktrees(out)
+	if len(got) != 4 {
+		t.Fatalf("expected 3 worktrees, got %+v", got)
+	}
+	if got[1].Path != filepath.FromSlash("/wt/api/1") || got[1].Branch != "cc/api-1" || got[1].Missing {
+		t.Errorf("unexpected worktree %+v", got[1])
+	}
+	if got[2].Branch != "" || !got[2].Missing || got[2].Head[0] != '3' {
+		t.Errorf("unexpected worktree %+v", got[2])
+	}
+	if !got[3].Locked || LockHolder(got[3].Reason) != 42 {
+		t.Errorf("unexpected worktree %+v", got[3])
+	}
+	if got[1].Locked || LockHolder("moved to a USB drive") != 0 {
+		t.Errorf("expected only cc's own locks to name a process")
+	}
+}
+
+func TestNewWorktree(t *testing.T) {
+	ctx := context.Background()
+	repo := t.TempDir()
+	gitRepo(t, repo)
+	parent := filepath.Join(t.TempDir(), "api")
+
+	first, err := NewWorktree(ctx, repo, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Path != filepath.Join(parent, "1") || first.Branch != "cc/api-1" {
+		t.Errorf("unexpected first worktree %+v", first)
+	}
+	second, err := NewWorktree(ctx, repo, parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Path != filepath.Join(parent, "2") || second.Branch != "cc/api-2" {
+		t.Errorf("unexpected second worktree %+v", second)
+	}
+	if s, err := Read(ctx, second.Path); err != nil || s.Branch != "cc/api-2" {
+		t.Errorf("expected the worktree to be on its branch, got %+v, %v", s, err)
+	}
+
+	if merged, err := Merged(ctx, repo, first.Branch); err != nil || !merged {
+		t.Errorf("expected a new branch to count as merged, got %v, %v", merged, err)
+	}
+	if _, err := run(ctx, first.Path, "-c", "user.name=cc", "-c", "user.email=cc@example.com",
+		"commit", "-q", "--allow-empty", "-m", "work"); err != nil {
+		t.Fatal(err)
+	}
+	if merged, err := Merged(ctx, repo, first.Branch); err != nil || merged {
+		t.Errorf("expected a branch with its own commit to be unmerged, got %v, %v", merged, err)
+	}
+
+	if err := LockWorktree(ctx, repo, second.Path, LockReason(7)); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveWorktree(ctx, repo, second.Path); err == nil {
+		t.Errorf("expected a locked worktree to be kept")
+	}
+	wts, err := Worktrees(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, wt := range wts {
+		if locked := wt.Branch == second.Branch; wt.Locked != locked || locked && LockHolder(wt.Reason) != 7 {
+			t.Errorf("unexpected lock on %+v", wt)
+		}
+	}
+	if err := UnlockWorktree(ctx, repo, second.Path); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveWorktree(ctx, repo, second.Path); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteBranch(ctx, repo, second.Branch); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(first.Path); err != nil {
+		t.Fatal(err)
+	}
+	if err := PruneWorktrees(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+	wts, err = Worktrees(ctx, repo)
+	if err != nil || len(wts) != 1 {
+		t.Errorf("expected only the main worktree to be left, got %+v, %v", wts, err)
+	}
+	if err := DeleteBranch(ctx, repo, first.Branch); err == nil {
+		t.Errorf("expected an unmerged branch to be kept")
+	}
+}
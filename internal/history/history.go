@@ -0,0 +1,158 @@
+// Package history records which projects are picked so the picker can list
+// the frecent ones first.
+package history
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// HalfLife is how long it takes a pick to count for half as much
+const HalfLife = 7 * 24 * time.Hour
+
+// Entry is one project that has been picked
+type Entry struct {
+	Path  string    `yaml:"path"` // project directory
+	Count int       `yaml:"count"`
+	Last  time.Time `yaml:"last"`
+}
+
+// Frecency is how often the project is picked, decayed by how long ago it
+// was last picked
+func (e Entry) Frecency(now time.Time) float64 {
+	age := now.Sub(e.Last)
+	if age < 0 {
+		age = 0
+	}
+	return float64(e.Count) * math.Pow(0.5, float64(age)/float64(HalfLife))
+}
+
+// History is every recorded pick
+type History struct {
+	Entries []Entry `yaml:"entries"`
+}
+
+// DefaultPath returns the history file next to the config file
+func DefaultPath() string {
+	return filepath.Join(filepath.Dir(config.DefaultConfigPath()), "history.yaml")
+}
+
+// Load reads the history, returning an empty one when none is recorded
+func Load(path string) (*History, error) {
+	if path == "" {
+		path = DefaultPath()
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &History{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	h := &History{}
+	if err := yaml.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %w", err)
+	}
+	return h, nil
+}
+
+// Save writes h, replacing the recorded history. The file is swapped in
+// whole, so a reader never sees it half written.
+func Save(h *History, path string) error {
+	if path == "" {
+		path = DefaultPath()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".history-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// Add records a pick of the project directory in the history at path. The
+// history is read just before, so picks other windows saved since this one
+// started are kept.
+func Add(path, project string, now time.Time) error {
+	h, err := Load(path)
+	if err != nil {
+		return err
+	}
+	h.Record(project, now)
+	return Save(h, path)
+}
+
+// Clear removes the recorded history
+func Clear(path string) error {
+	if path == "" {
+		path = DefaultPath()
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear history: %w", err)
+	}
+	return nil
+}
+
+// Record counts a pick of the project at path
+func (h *History) Record(path string, now time.Time) {
+	path = filepath.Clean(path)
+	for i := range h.Entries {
+		if h.Entries[i].Path == path {
+			h.Entries[i].Count++
+			h.Entries[i].Last = now
+			return
+		}
+	}
+	h.Entries = append(h.Entries, Entry{Path: path, Count: 1, Last: now})
+}
+
+// Ranked returns the entries most frecent first
+func (h *History) Ranked(now time.Time) []Entry {
+	ranked := append([]Entry(nil), h.Entries...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Frecency(now) > ranked[j].Frecency(now)
+	})
+	return ranked
+}
+
+// In returns the entries for projects directly inside root, keyed by
+// project name
+func (h *History) In(root string) map[string]Entry {
+	root = filepath.Clean(root)
+	out := map[string]Entry{}
+	for _, e := range h.Entries {
+		if filepath.Dir(e.Path) == root {
+			out[filepath.Base(e.Path)] = e
+		}
+	}
+	return out
+}
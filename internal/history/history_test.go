@@ -0,0 +1,101 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordAndRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.yaml")
+	h, err := Load(path)
+	if err != nil || len(h.Entries) != 0 {
+		t.Fatalf("expected an empty history before the first save, got %+v, %v", h, err)
+	}
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	h.Record("/dev/api", now)
+	h.Record("/dev/web/", now)
+	h.Record("/dev/api", now.Add(time.Hour))
+	if err := Save(h, path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Entries) != 2 || got.Entries[0].Count != 2 || !got.Entries[0].Last.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected entries %+v", got.Entries)
+	}
+	if got.Entries[1].Path != filepath.Clean("/dev/web") {
+		t.Errorf("expected paths to be cleaned, got %q", got.Entries[1].Path)
+	}
+
+	if err := Clear(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clear(path); err != nil {
+		t.Errorf("clearing twice should not fail: %v", err)
+	}
+	if h, _ := Load(path); len(h.Entries) != 0 {
+		t.Errorf("expected an empty history after clear, got %+v", h.Entries)
+	}
+}
+
+func TestAddKeepsOtherPicks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.yaml")
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// Two windows picking one after the other both count
+	if err := Add(path, "/dev/api", now); err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(path, "/dev/web", now); err != nil {
+		t.Fatal(err)
+	}
+	h, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Entries) != 2 {
+		t.Errorf("expected both picks, got %+v", h.Entries)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the history file, got %d entries", len(entries))
+	}
+}
+
+func TestFrecencyDecays(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	e := Entry{Count: 4, Last: now.Add(-HalfLife)}
+	if got := e.Frecency(now); got != 2 {
+		t.Errorf("expected half the count after one half-life, got %v", got)
+	}
+
+	h := &History{Entries: []Entry{
+		{Path: "/dev/old", Count: 10, Last: now.Add(-8 * HalfLife)},
+		{Path: "/dev/new", Count: 2, Last: now},
+		{Path: "/dev/mid", Count: 3, Last: now.Add(-HalfLife)},
+	}}
+	ranked := h.Ranked(now)
+	if ranked[0].Path != "/dev/new" || ranked[1].Path != "/dev/mid" || ranked[2].Path != "/dev/old" {
+		t.Errorf("unexpected ranking %+v", ranked)
+	}
+}
+
+func TestIn(t *testing.T) {
+	root := filepath.FromSlash("/dev")
+	h := &History{Entries: []Entry{
+		{Path: filepath.Join(root, "api")},
+		{Path: filepath.Join(root, "nested", "web")},
+		{Path: filepath.FromSlash("/other/cli")},
+	}}
+	got := h.In(root + string(filepath.Separator))
+	if _, ok := got["api"]; len(got) != 1 || !ok {
+		t.Errorf("expected only api under %s, got %v", root, got)
+	}
+}
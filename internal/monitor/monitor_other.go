@@ -0,0 +1,10 @@
+//go:build !windows
+
+package monitor
+
+import "fmt"
+
+// Detect returns a list of all connected monitors
+func Detect() ([]Monitor, error) {
+	return nil, fmt.Errorf("monitor detection is only supported on Windows")
+}
@@ -0,0 +1,119 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Change describes how the monitor topology moved between two detections.
+// Monitors are matched by geometry, so a display that changes resolution
+// shows up as one removal and one addition.
+type Change struct {
+	Added   []Monitor
+	Removed []Monitor
+}
+
+// Empty reports whether the topology is unchanged
+func (c Change) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// String summarises the change for display
+func (c Change) String() string {
+	if c.Empty() {
+		return "no change"
+	}
+	return fmt.Sprintf("%d added, %d removed", len(c.Added), len(c.Removed))
+}
+
+// Diff compares two detections and returns the monitors that appeared and disappeared
+func Diff(before, after []Monitor) Change {
+	var c Change
+	for _, m := range after {
+		if !containsGeometry(before, m) {
+			c.Added = append(c.Added, m)
+		}
+	}
+	for _, m := range before {
+		if !containsGeometry(after, m) {
+			c.Removed = append(c.Removed, m)
+		}
+	}
+	return c
+}
+
+func containsGeometry(list []Monitor, m Monitor) bool {
+	for _, o := range list {
+		if o.X == m.X && o.Y == m.Y && o.Width == m.Width && o.Height == m.Height {
+			return true
+		}
+	}
+	return false
+}
+
+// Watcher polls for monitor changes and reports a new topology once it
+// has stayed the same for the debounce period. Docks that flap while
+// reconnecting therefore produce a single callback.
+type Watcher struct {
+	Detect   func() ([]Monitor, error) // defaults to the package Detect
+	Interval time.Duration             // time between polls
+	Debounce time.Duration             // how long a new topology must hold before it is reported
+}
+
+// Watch blocks until ctx is cancelled, calling onChange with the previous
+// and current monitors each time a settled topology change is seen.
+// Detection errors are treated as transient and skipped; only an error on
+// the very first detection is returned.
+func (w *Watcher) Watch(ctx context.Context, onChange func(before, after []Monitor, c Change)) error {
+	detect := w.Detect
+	if detect == nil {
+		detect = Detect
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	stable, err := detect()
+	if err != nil {
+		return fmt.Errorf("failed to detect monitors: %w", err)
+	}
+
+	var pending []Monitor
+	var pendingSince time.Time
+	hasPending := false
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := detect()
+			if err != nil {
+				continue
+			}
+
+			if Diff(stable, current).Empty() {
+				hasPending = false
+				continue
+			}
+
+			if !hasPending || !Diff(pending, current).Empty() {
+				pending = current
+				pendingSince = now
+				hasPending = true
+			}
+			if now.Sub(pendingSince) < w.Debounce {
+				continue
+			}
+
+			onChange(stable, current, Diff(stable, current))
+			stable = current
+			hasPending = false
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	laptop   = Monitor{X: 0, Y: 0, Width: 1536, Height: 960, Primary: true}
+	external = Monitor{X: 1536, Y: 0, Width: 1920, Height: 1080}
+)
+
+func TestDiff(t *testing.T) {
+	c := Diff([]Monitor{laptop}, []Monitor{laptop, external})
+	if len(c.Added) != 1 || c.Added[0] != external {
+		t.Errorf("expected external monitor added, got %+v", c.Added)
+	}
+	if len(c.Removed) != 0 {
+		t.Errorf("expected nothing removed, got %+v", c.Removed)
+	}
+
+	c = Diff([]Monitor{laptop, external}, []Monitor{laptop})
+	if len(c.Removed) != 1 || c.Removed[0] != external {
+		t.Errorf("expected external monitor removed, got %+v", c.Removed)
+	}
+
+	// Names are synthesized from position, so they must not affect the diff
+	renamed := laptop
+	renamed.Name = "Monitor 2"
+	if c := Diff([]Monitor{laptop}, []Monitor{renamed}); !c.Empty() {
+		t.Errorf("expected rename to be ignored, got %s", c)
+	}
+
+	resized := external
+	resized.Width = 2560
+	c = Diff([]Monitor{laptop, external}, []Monitor{laptop, resized})
+	if len(c.Added) != 1 || len(c.Removed) != 1 {
+		t.Errorf("expected resize to be one add and one remove, got %s", c)
+	}
+}
+
+// scriptedDetect returns each topology in turn, repeating the last one
+type scriptedDetect struct {
+	mu    sync.Mutex
+	steps [][]Monitor
+	calls int
+}
+
+func (s *scriptedDetect) detect() ([]Monitor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.calls
+	if i >= len(s.steps) {
+		i = len(s.steps) - 1
+	}
+	s.calls++
+	return s.steps[i], nil
+}
+
+func runWatcher(t *testing.T, steps [][]Monitor, debounce time.Duration, polls int) [][]Monitor {
+	t.Helper()
+	sd := &scriptedDetect{steps: steps}
+	w := &Watcher{Detect: sd.detect, Interval: time.Millisecond, Debounce: debounce}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var got [][]Monitor
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Watch(ctx, func(before, after []Monitor, c Change) {
+			mu.Lock()
+			got = append(got, after)
+			mu.Unlock()
+		})
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		sd.mu.Lock()
+		n := sd.calls
+		sd.mu.Unlock()
+		if n > polls {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return got
+}
+
+func TestWatcherReportsSettledChange(t *testing.T) {
+	steps := [][]Monitor{
+		{laptop},
+		{laptop, external},
+	}
+	got := runWatcher(t, steps, 0, 20)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(got))
+	}
+	if len(got[0]) != 2 {
+		t.Errorf("expected 2 monitors after change, got %d", len(got[0]))
+	}
+}
+
+func TestWatcherDebouncesFlapping(t *testing.T) {
+	// The dock drops in and out before settling back where it started
+	steps := [][]Monitor{
+		{laptop, external},
+		{laptop},
+		{laptop, external},
+		{laptop},
+		{laptop, external},
+	}
+	got := runWatcher(t, steps, time.Hour, 20)
+	if len(got) != 0 {
+		t.Errorf("expected flapping to be suppressed, got %d changes", len(got))
+	}
+}
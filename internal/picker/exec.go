@@ -0,0 +1,104 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+)
+
+// command resolves a command line such as "claude --flag" and builds the
+// environment to run it with
+func command(cmdline string, env map[string]string) (path string, argv, environ []string, err error) {
+	argv = strings.Fields(cmdline)
+	if len(argv) == 0 {
+		return "", nil, nil, fmt.Errorf("no command to run")
+	}
+	path, err = exec.LookPath(argv[0])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to find %s: %w", argv[0], err)
+	}
+
+	return path, argv, environWith(env), nil
+}
+
+// environWith returns the process environment with env added. Inherited
+// copies of env's variables are dropped, since a process that looks them up
+// may see the first copy rather than the last.
+func environWith(env map[string]string) []string {
+	var environ []string
+	for _, kv := range os.Environ() {
+		k, _, _ := strings.Cut(kv, "=")
+		if !hasKey(env, k) {
+			environ = append(environ, kv)
+		}
+	}
+	for k, v := range env {
+		environ = append(environ, k+"="+v)
+	}
+	return environ
+}
+
+// hasKey reports whether env sets the variable k, ignoring case on Windows
+func hasKey(env map[string]string, k string) bool {
+	if _, ok := env[k]; ok {
+		return true
+	}
+	if runtime.GOOS == "windows" {
+		for name := range env {
+			if strings.EqualFold(name, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RunTool runs cmdline in dir with env added and waits for it, returning
+// its exit status. Interrupts meant for the tool do not end this process.
+func RunTool(cmdline, dir string, env map[string]string) (int, error) {
+	path, argv, environ, err := command(cmdline, env)
+	if err != nil {
+		return 0, err
+	}
+
+	cmd := exec.Command(path, argv[1:]...)
+	cmd.Dir = dir
+	cmd.Env = environ
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// Caught rather than ignored, since an ignored signal stays ignored in
+	// the tool too
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// finish runs cmdline like RunTool, calls done if there is one, and then
+// exits with the tool's status unless that is 0. It is Exec where a process
+// cannot replace itself.
+func finish(cmdline, dir string, env map[string]string, done func(), exit func(int)) error {
+	code, err := RunTool(cmdline, dir, env)
+	if done != nil {
+		done()
+	}
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		exit(code)
+	}
+	return nil
+}
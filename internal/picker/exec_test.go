@@ -0,0 +1,42 @@
+package picker
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestEnvironWithReplacesInherited(t *testing.T) {
+	t.Setenv("CLAUDE_CONFIG_DIR", "/home/personal")
+
+	var got []string
+	for _, kv := range environWith(map[string]string{"CLAUDE_CONFIG_DIR": "/cfg/work"}) {
+		if strings.HasPrefix(kv, "CLAUDE_CONFIG_DIR=") {
+			got = append(got, kv)
+		}
+	}
+	if len(got) != 1 || got[0] != "CLAUDE_CONFIG_DIR=/cfg/work" {
+		t.Errorf("expected only the profile's config dir, got %q", got)
+	}
+}
+
+func TestFinishCallsDoneBeforeExiting(t *testing.T) {
+	if _, err := exec.LookPath("false"); err != nil {
+		t.Skip("false not installed")
+	}
+	var calls []string
+	done := func() { calls = append(calls, "done") }
+	exit := func(code int) { calls = append(calls, "exit") }
+	if err := finish("false", t.TempDir(), nil, done, exit); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(calls, " ") != "done exit" {
+		t.Errorf("expected done before exiting, got %v", calls)
+	}
+
+	// A tool that could not start still lets go of what done frees
+	calls = nil
+	if err := finish("cc-no-such-tool", t.TempDir(), nil, done, exit); err == nil || strings.Join(calls, " ") != "done" {
+		t.Errorf("expected done and an error, got %v, %v", calls, err)
+	}
+}
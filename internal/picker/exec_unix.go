@@ -0,0 +1,29 @@
+//go:build !windows
+
+package picker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// Exec replaces the process with cmdline running in dir with env added.
+// done is never called: the tool keeps cc's process ID, so whatever is held
+// for that process is let go when the tool exits.
+func Exec(cmdline, dir string, env map[string]string, done func()) error {
+	path, argv, environ, err := command(cmdline, env)
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("failed to enter %s: %w", dir, err)
+	}
+	return syscall.Exec(path, argv, environ)
+}
+
+// shell runs line through the POSIX shell
+func shell(line string) *exec.Cmd {
+	return exec.Command("sh", "-c", line)
+}
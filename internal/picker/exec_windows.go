@@ -0,0 +1,17 @@
+package picker
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Exec runs cmdline in dir with env added, calls done once it finishes and
+// exits with its status; Windows cannot replace a running process
+func Exec(cmdline, dir string, env map[string]string, done func()) error {
+	return finish(cmdline, dir, env, done, os.Exit)
+}
+
+// shell runs line through cmd.exe
+func shell(line string) *exec.Cmd {
+	return exec.Command("cmd", "/C", line)
+}
@@ -0,0 +1,13 @@
+package picker
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
+
+// selectRead waits for the descriptors in r to be readable
+func selectRead(n int, r *syscall.FdSet, timeout *syscall.Timeval) error {
+	return syscall.Select(n, r, nil, nil, timeout)
+}
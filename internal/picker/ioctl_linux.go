@@ -0,0 +1,14 @@
+package picker
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
+
+// selectRead waits for the descriptors in r to be readable
+func selectRead(n int, r *syscall.FdSet, timeout *syscall.Timeval) error {
+	_, err := syscall.Select(n, r, nil, nil, timeout)
+	return err
+}
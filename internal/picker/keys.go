@@ -0,0 +1,100 @@
+package picker
+
+import "unicode/utf8"
+
+// KeyType identifies a key decoded from terminal input
+type KeyType int
+
+const (
+	KeyRune KeyType = iota // printable character in Key.Rune
+	KeyUp
+	KeyDown
+	KeyPgUp
+	KeyPgDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyBackspace
+	KeyTab
+	KeyEsc
+	KeyCtrl // control chord, letter in Key.Rune
+)
+
+// Key is one keypress
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+// escapes maps the VT sequences sent for special keys. Windows consoles send
+// the same ones once virtual terminal input is enabled.
+var escapes = map[string]KeyType{
+	"\x1b[A": KeyUp, "\x1bOA": KeyUp,
+	"\x1b[B": KeyDown, "\x1bOB": KeyDown,
+	"\x1b[5~": KeyPgUp,
+	"\x1b[6~": KeyPgDown,
+	"\x1b[H":  KeyHome, "\x1bOH": KeyHome, "\x1b[1~": KeyHome,
+	"\x1b[F": KeyEnd, "\x1bOF": KeyEnd, "\x1b[4~": KeyEnd,
+}
+
+// ParseKeys decodes one read from the terminal into keys. A lone ESC is the
+// Escape key; unknown escape sequences are dropped.
+func ParseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			n := escapeLen(b)
+			if n == 1 {
+				keys = append(keys, Key{Type: KeyEsc})
+			} else if t, ok := escapes[string(b[:n])]; ok {
+				keys = append(keys, Key{Type: t})
+			}
+			b = b[n:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, Key{Type: KeyEnter})
+			// A CRLF pair is one Enter
+			if c == '\r' && len(b) > 1 && b[1] == '\n' {
+				b = b[1:]
+			}
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, Key{Type: KeyBackspace})
+			b = b[1:]
+		case c == '\t':
+			keys = append(keys, Key{Type: KeyTab})
+			b = b[1:]
+		case c < 0x20:
+			keys = append(keys, Key{Type: KeyCtrl, Rune: rune('a' + c - 1)})
+			b = b[1:]
+		default:
+			r, n := utf8.DecodeRune(b)
+			keys = append(keys, Key{Type: KeyRune, Rune: r})
+			b = b[n:]
+		}
+	}
+	return keys
+}
+
+// escapeLen returns the length of the escape sequence at the start of b:
+// ESC [ params final, ESC O final, or a lone ESC
+func escapeLen(b []byte) int {
+	if len(b) < 2 {
+		return 1
+	}
+	switch b[1] {
+	case 'O':
+		if len(b) >= 3 {
+			return 3
+		}
+		return 2
+	case '[':
+		for i := 2; i < len(b); i++ {
+			if b[i] >= 0x40 && b[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(b)
+	}
+	return 1
+}
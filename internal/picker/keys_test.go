@@ -0,0 +1,30 @@
+package picker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Key
+	}{
+		{"ab", []Key{{Type: KeyRune, Rune: 'a'}, {Type: KeyRune, Rune: 'b'}}},
+		{"\x1b", []Key{{Type: KeyEsc}}},
+		{"\x1b[A\x1b[B", []Key{{Type: KeyUp}, {Type: KeyDown}}},
+		{"\x1bOA", []Key{{Type: KeyUp}}},
+		{"\x1b[5~\x1b[6~", []Key{{Type: KeyPgUp}, {Type: KeyPgDown}}},
+		{"\r\n", []Key{{Type: KeyEnter}}},
+		{"\r", []Key{{Type: KeyEnter}}},
+		{"\x7f\x08", []Key{{Type: KeyBackspace}, {Type: KeyBackspace}}},
+		{"\x03", []Key{{Type: KeyCtrl, Rune: 'c'}}},
+		{"é", []Key{{Type: KeyRune, Rune: 'é'}}},
+		{"\x1b[1;5C", nil}, // ctrl+right is not bound
+	}
+	for _, tt := range tests {
+		if got := ParseKeys([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseKeys(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
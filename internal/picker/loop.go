@@ -0,0 +1,135 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+// afterExit is what loop mode does once the tool exits
+type afterExit int
+
+const (
+	backToPicker afterExit = iota
+	restartTool
+	switchAccount
+	closeWindow
+)
+
+// repeat starts the tool for res and waits for it, then asks what to do
+// next until the window is to close or go back to the picker, reporting
+// which
+func repeat(t Terminal, s Session, res Result) (back bool, err error) {
+	l := prepare(t, s, res)
+	if l.unlock != nil {
+		defer l.unlock()
+	}
+	code, toolErr := 0, error(nil)
+	start := true
+	for {
+		if start {
+			preLaunch(t, l.preLaunch, l.dir, l.env)
+			code, toolErr = RunTool(l.command, l.dir, l.env)
+		}
+		start = true
+
+		next, err := askAfterExit(t, s, res.Project, code, toolErr)
+		if err != nil {
+			return false, err
+		}
+		switch next {
+		case backToPicker:
+			return true, nil
+		case closeWindow:
+			return false, nil
+		case switchAccount:
+			p, err := chooseAccount(t, s, res.Project)
+			if errors.Is(err, ErrCancelled) {
+				start = false
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			res.Profile = p
+			l.env = Env(p)
+			if s.Title != "" {
+				fmt.Fprintf(t, "\x1b]0;%s\x07", title(s.Title, res))
+			}
+			fmt.Fprintf(t, "\n  %s>%s %s%s%s %s· %s%s\n\n", ui.BrGreen, ui.Reset, ui.BrWhite, res.Project, ui.Reset, ui.DkGray, p.Name, ui.Reset)
+		}
+	}
+}
+
+// askAfterExit reports how the tool exited and reads what to do next
+func askAfterExit(t Terminal, s Session, project string, code int, toolErr error) (afterExit, error) {
+	switch {
+	case toolErr != nil:
+		fmt.Fprintf(t, "\n  %s%s%s\n", ui.BrYell, toolErr, ui.Reset)
+	case code != 0:
+		fmt.Fprintf(t, "\n  %s%s exited in %s with status %d%s\n", ui.DkGray, s.Label, project, code, ui.Reset)
+	default:
+		fmt.Fprintf(t, "\n  %s%s exited in %s%s\n", ui.DkGray, s.Label, project, ui.Reset)
+	}
+	canSwitch := len(s.Profiles) > 1
+	fmt.Fprintf(t, "  %senter%s picker  %sr%s restart", ui.DkGray, ui.Reset, ui.DkGray, ui.Reset)
+	if canSwitch {
+		fmt.Fprintf(t, "  %sa%s switch account", ui.DkGray, ui.Reset)
+	}
+	fmt.Fprintf(t, "  %sq%s close\n", ui.DkGray, ui.Reset)
+
+	restore, err := t.Raw()
+	if err != nil {
+		return closeWindow, fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer restore()
+
+	buf := make([]byte, 256)
+	for {
+		n, err := t.Read(buf)
+		if err != nil {
+			return closeWindow, fmt.Errorf("failed to read input: %w", err)
+		}
+		for _, k := range ParseKeys(buf[:n]) {
+			if next, ok := exitKey(k, canSwitch); ok {
+				return next, nil
+			}
+		}
+	}
+}
+
+// exitKey maps a key pressed after the tool exited to what to do next
+func exitKey(k Key, canSwitch bool) (afterExit, bool) {
+	switch k.Type {
+	case KeyEnter:
+		return backToPicker, true
+	case KeyEsc:
+		return closeWindow, true
+	case KeyCtrl:
+		if k.Rune == 'c' || k.Rune == 'd' {
+			return closeWindow, true
+		}
+	case KeyRune:
+		switch k.Rune {
+		case 'p':
+			return backToPicker, true
+		case 'r':
+			return restartTool, true
+		case 'a':
+			return switchAccount, canSwitch
+		case 'q':
+			return closeWindow, true
+		}
+	}
+	return 0, false
+}
+
+// chooseAccount asks which account to start project's tool with next
+func chooseAccount(t Terminal, s Session, project string) (*config.Profile, error) {
+	m := New(Options{Label: s.Label, Profiles: s.Profiles})
+	m.choose(project)
+	res, err := run(t, m)
+	return res.Profile, err
+}
@@ -0,0 +1,70 @@
+package picker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+)
+
+func TestAskAfterExit(t *testing.T) {
+	s := Session{Label: "cc", Profiles: []config.Profile{{Name: "work"}}}
+
+	// Keys that mean nothing here are skipped
+	vt := &virtualTerminal{input: []string{"x", "a", "r"}}
+	next, err := askAfterExit(vt, s, "api", 2, nil)
+	if err != nil || next != restartTool {
+		t.Errorf("expected a restart, got %v, %v", next, err)
+	}
+	out := vt.out.String()
+	if !strings.Contains(out, "cc exited in api with status 2") || strings.Contains(out, "switch account") {
+		t.Errorf("unexpected prompt %q", out)
+	}
+	if !vt.raw || !vt.restored {
+		t.Errorf("expected raw mode to be entered and restored")
+	}
+
+	s.Profiles = append(s.Profiles, config.Profile{Name: "home"})
+	vt = &virtualTerminal{input: []string{"a"}}
+	if next, err := askAfterExit(vt, s, "api", 0, errors.New("failed to find claude")); err != nil || next != switchAccount {
+		t.Errorf("expected an account switch, got %v, %v", next, err)
+	}
+	if out := vt.out.String(); !strings.Contains(out, "failed to find claude") || !strings.Contains(out, "switch account") {
+		t.Errorf("unexpected prompt %q", out)
+	}
+}
+
+func TestExitKey(t *testing.T) {
+	for _, tc := range []struct {
+		key  Key
+		want afterExit
+	}{
+		{Key{Type: KeyEnter}, backToPicker},
+		{Key{Type: KeyRune, Rune: 'p'}, backToPicker},
+		{Key{Type: KeyRune, Rune: 'r'}, restartTool},
+		{Key{Type: KeyRune, Rune: 'a'}, switchAccount},
+		{Key{Type: KeyRune, Rune: 'q'}, closeWindow},
+		{Key{Type: KeyEsc}, closeWindow},
+		{Key{Type: KeyCtrl, Rune: 'd'}, closeWindow},
+	} {
+		if got, ok := exitKey(tc.key, true); !ok || got != tc.want {
+			t.Errorf("%+v: got %v, %v; want %v", tc.key, got, ok, tc.want)
+		}
+	}
+	if _, ok := exitKey(Key{Type: KeyRune, Rune: 'a'}, false); ok {
+		t.Errorf("expected no account switch with a single account")
+	}
+}
+
+func TestChooseAccount(t *testing.T) {
+	s := Session{Label: "cc", Profiles: []config.Profile{{Name: "work"}, {Name: "home"}}}
+	vt := &virtualTerminal{height: 24, input: []string{"2"}}
+	p, err := chooseAccount(vt, s, "api")
+	if err != nil || p == nil || p.Name != "home" {
+		t.Errorf("expected home, got %v, %v", p, err)
+	}
+	if !strings.Contains(vt.out.String(), "api") {
+		t.Errorf("expected the project to be shown")
+	}
+}
@@ -0,0 +1,814 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/fuzzy"
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/project"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+// ErrCancelled is returned when the picker is closed without a choice
+var ErrCancelled = errors.New("picker cancelled")
+
+const inverse = "\033[7m"
+
+// startY is the number of header rows above the filter line
+const startY = 3
+
+// phase is the step the picker is on
+type phase int
+
+const (
+	pickProject phase = iota
+	pickCreate
+	pickAccount
+	done
+	cancelled
+)
+
+// Options configure a picker
+type Options struct {
+	Label    string // tool name shown in the header, e.g. "cc"
+	Projects []string
+	Profiles []config.Profile
+	// Recent holds when each project was last used; among equally good
+	// matches the most recent is listed first
+	Recent map[string]time.Time
+	// Frecent are the projects listed in a Recent section above the full
+	// list while the filter is empty, best first
+	Frecent []string
+	// Favorites are pinned above every other project; Hidden are left out
+	// until revealed
+	Favorites []string
+	Hidden    []string
+	// OnMark persists a project's new pinned and hidden state. A nil OnMark
+	// disables pinning and hiding.
+	OnMark func(project string, favorite, hidden bool) error
+	// Status is the git state of each project, shown beside it and used by
+	// the ":dirty" style filter prefixes; projects without one show nothing
+	Status map[string]git.Status
+	// Statuses delivers the statuses still being read when the picker
+	// opens, each shown as it arrives. Until it is closed, projects without
+	// a status are unknown: marked as such and kept by the filter prefixes.
+	Statuses <-chan git.ProjectStatus
+	// Types are the detected project types, shown as badges
+	Types map[string]project.Info
+	// Preview describes a project in a pane beside the list. It is called
+	// only for the highlighted project, once each and off the UI path, so
+	// it may be slow; nil disables the pane.
+	Preview func(project string) []string
+	// Templates are offered when creating a project; CanCreate turns on the
+	// create action shown when the filter matches nothing
+	Templates []string
+	CanCreate bool
+	// Remote resolves a filter that names a git repository to the remote to
+	// clone; nil turns off the clone action
+	Remote func(ref string) (string, bool)
+	// Worktrees turns on ^w, which starts the highlighted project in a new
+	// git worktree of its own
+	Worktrees bool
+	// Multi turns on marking several projects with space; the first marked
+	// is the Result's Project and the rest are listed in Also
+	Multi bool
+	// Selected is highlighted at the start, e.g. the project just left
+	Selected string
+}
+
+// Result is what the user picked
+type Result struct {
+	Project  string
+	Profile  *config.Profile // nil when no profiles are configured
+	Create   *Create         // set when Project is to be created
+	Clone    string          // remote to clone into Project first
+	Worktree bool            // start in a new git worktree of Project
+	Also     []string        // more projects to start, each in a window of its own
+	Tool     string          // "cc" or "cx" to start instead of the session's, beating any override
+}
+
+// Create is how a new project should be set up
+type Create struct {
+	Template string // "" for none
+	GitInit  bool
+}
+
+// Model is the picker state, driven by Update and drawn by View. It does no
+// I/O so it can be tested key by key.
+type Model struct {
+	opts     Options
+	phase    phase
+	filter   string
+	filtered []row
+	sel      int
+	offset   int // index of the first visible row
+	maxShow  int // visible rows, from the last View
+	chosen   string
+	profile  *config.Profile
+
+	favorite   map[string]bool
+	hidden     map[string]bool
+	showHidden bool
+	status     string // error from the last OnMark, shown under the list
+	sort       sortMode
+
+	statuses <-chan git.ProjectStatus // nil once every status is in
+
+	hidePreview bool
+	previews    map[string][]string
+	wanted      string          // project the last View had no preview for
+	loading     map[string]bool // previews being loaded
+
+	create   *Create
+	template int // selected template, 0 being none
+	clone    string
+	worktree bool
+
+	marked []string // in the order they were marked
+}
+
+// row is a project in the filtered list, or a section heading that cannot
+// be selected
+type row struct {
+	name    string
+	matched []int // rune offsets of the characters matching the filter
+	heading string
+	create  bool   // the action creating a project called name
+	clone   string // the action cloning this remote into name
+}
+
+// New returns a picker over opts.Projects
+func New(opts Options) *Model {
+	m := &Model{opts: opts, maxShow: 12, favorite: map[string]bool{}, hidden: map[string]bool{}}
+	for _, p := range opts.Favorites {
+		m.favorite[p] = true
+	}
+	for _, p := range opts.Hidden {
+		m.hidden[p] = true
+	}
+	if opts.Statuses != nil {
+		m.statuses = opts.Statuses
+		m.opts.Status = maps.Clone(opts.Status)
+		if m.opts.Status == nil {
+			m.opts.Status = map[string]git.Status{}
+		}
+	}
+	m.refilter()
+	if opts.Selected != "" {
+		m.selectName(opts.Selected)
+	}
+	return m
+}
+
+// Update applies one key and reports whether the picker has finished
+func (m *Model) Update(k Key) bool {
+	if k.Type == KeyCtrl && k.Rune == 'c' {
+		m.phase = cancelled
+		return true
+	}
+	switch m.phase {
+	case pickProject:
+		m.updateProject(k)
+	case pickCreate:
+		m.updateCreate(k)
+	case pickAccount:
+		m.updateAccount(k)
+	}
+	return m.phase == done || m.phase == cancelled
+}
+
+func (m *Model) updateProject(k Key) {
+	switch k.Type {
+	case KeyEsc:
+		m.phase = cancelled
+	case KeyEnter:
+		switch {
+		case len(m.marked) > 0:
+			m.choose(m.marked[0])
+		case m.sel < len(m.filtered) && m.filtered[m.sel].create:
+			m.chosen = m.filtered[m.sel].name
+			m.create = &Create{GitInit: true}
+			m.template = 0
+			m.phase = pickCreate
+		case m.sel < len(m.filtered) && m.filtered[m.sel].clone != "":
+			m.clone = m.filtered[m.sel].clone
+			m.choose(m.filtered[m.sel].name)
+		case m.selected() != "":
+			m.choose(m.selected())
+		}
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPgUp:
+		m.move(-m.maxShow)
+	case KeyPgDown:
+		m.move(m.maxShow)
+	case KeyHome:
+		m.move(-len(m.filtered))
+	case KeyEnd:
+		m.move(len(m.filtered))
+	case KeyBackspace:
+		if m.filter != "" {
+			r := []rune(m.filter)
+			m.filter = string(r[:len(r)-1])
+			m.refilter()
+		}
+	case KeyRune:
+		switch {
+		case k.Rune > ' ' || k.Rune == ' ' && endsInPrefix(m.filter):
+			m.filter += string(k.Rune)
+			m.refilter()
+		case k.Rune == ' ' && m.opts.Multi:
+			if name := m.selected(); name != "" {
+				m.toggleMark(name)
+				m.move(1)
+			}
+		}
+	case KeyTab:
+		name := m.selected()
+		m.sort = (m.sort + 1) % sortMode(len(sortNames))
+		m.refilter()
+		m.selectName(name)
+	case KeyCtrl:
+		switch k.Rune {
+		case 'p':
+			if name := m.selected(); name != "" {
+				pin := !m.favorite[name]
+				m.mark(name, pin, m.hidden[name] && !pin)
+			}
+		case 'x':
+			if name := m.selected(); name != "" {
+				hide := !m.hidden[name]
+				m.mark(name, m.favorite[name] && !hide, hide)
+			}
+		case 'w':
+			name := m.selected()
+			if len(m.marked) > 0 {
+				name = m.marked[0]
+			}
+			if name != "" && m.opts.Worktrees {
+				m.worktree = true
+				m.choose(name)
+			}
+		case 'o':
+			m.hidePreview = !m.hidePreview
+		case 'a':
+			name := m.selected()
+			m.showHidden = !m.showHidden
+			m.refilter()
+			m.selectName(name)
+		}
+	}
+}
+
+// toggleMark marks or unmarks name for starting along with the others
+func (m *Model) toggleMark(name string) {
+	if i := m.markIndex(name); i >= 0 {
+		m.marked = append(m.marked[:i], m.marked[i+1:]...)
+	} else {
+		m.marked = append(m.marked, name)
+	}
+}
+
+// markIndex returns where name is among the marked projects, or -1
+func (m *Model) markIndex(name string) int {
+	for i, p := range m.marked {
+		if p == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// hiddenCount returns how many listed projects are hidden
+func (m *Model) hiddenCount() int {
+	n := 0
+	for _, p := range m.opts.Projects {
+		if m.hidden[p] {
+			n++
+		}
+	}
+	return n
+}
+
+// updateCreate picks a template and whether to git init the new project
+func (m *Model) updateCreate(k Key) {
+	switch k.Type {
+	case KeyEsc:
+		m.chosen = ""
+		m.create = nil
+		m.phase = pickProject
+	case KeyUp:
+		m.template = max(0, m.template-1)
+	case KeyDown:
+		m.template = min(len(m.opts.Templates), m.template+1)
+	case KeyRune:
+		if k.Rune == ' ' {
+			m.create.GitInit = !m.create.GitInit
+		}
+	case KeyEnter:
+		if m.template > 0 {
+			m.create.Template = m.opts.Templates[m.template-1]
+		}
+		m.choose(m.chosen)
+	}
+}
+
+// selected returns the highlighted project, or "" when there is none
+func (m *Model) selected() string {
+	if m.sel < len(m.filtered) && !m.filtered[m.sel].create && m.filtered[m.sel].clone == "" {
+		return m.filtered[m.sel].name
+	}
+	return ""
+}
+
+// mark pins or hides project, keeping it selected while it stays visible.
+// Pinning unhides and hiding unpins, as in the config.
+func (m *Model) mark(project string, favorite, hidden bool) {
+	if m.opts.OnMark == nil {
+		return
+	}
+	if err := m.opts.OnMark(project, favorite, hidden); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = ""
+	m.favorite[project] = favorite
+	m.hidden[project] = hidden
+
+	pos := m.sel
+	m.refilter()
+	if !m.selectName(project) && len(m.filtered) > 0 {
+		// Hidden: select whatever took its place
+		m.sel = min(pos, len(m.filtered)-1)
+		m.move(0)
+	}
+}
+
+// selectName moves the selection to project, reporting whether it is listed
+func (m *Model) selectName(project string) bool {
+	for i, r := range m.filtered {
+		if r.heading == "" && r.name == project {
+			m.sel = i
+			m.scroll()
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) updateAccount(k Key) {
+	switch k.Type {
+	case KeyEsc:
+		m.phase = cancelled
+	case KeyRune:
+		n := int(k.Rune - '0')
+		if n >= 1 && n <= len(m.opts.Profiles) && n <= 9 {
+			m.profile = &m.opts.Profiles[n-1]
+			m.phase = done
+		}
+	}
+}
+
+// choose picks project and moves on to the account step when there is a
+// choice of accounts
+func (m *Model) choose(project string) {
+	m.chosen = project
+	switch len(m.opts.Profiles) {
+	case 0:
+		m.phase = done
+	case 1:
+		m.profile = &m.opts.Profiles[0]
+		m.phase = done
+	default:
+		m.phase = pickAccount
+	}
+}
+
+func (m *Model) move(delta int) {
+	if len(m.filtered) == 0 {
+		return
+	}
+	sel := min(max(m.sel+delta, 0), len(m.filtered)-1)
+
+	// Step over headings the way we were moving, or back if the list ends
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	for _, dir := range []int{step, -step} {
+		for i := sel; i >= 0 && i < len(m.filtered); i += dir {
+			if m.filtered[i].heading == "" {
+				m.sel = i
+				m.scroll()
+				return
+			}
+		}
+	}
+}
+
+// scroll keeps the selection, and the heading right above it, inside the
+// visible rows
+func (m *Model) scroll() {
+	top := m.sel
+	if top > 0 && m.filtered[top-1].heading != "" {
+		top--
+	}
+	if top < m.offset {
+		m.offset = top
+	}
+	if m.sel >= m.offset+m.maxShow {
+		m.offset = m.sel - m.maxShow + 1
+	}
+	if maxOff := max(0, len(m.filtered)-m.maxShow); m.offset > maxOff {
+		m.offset = maxOff
+	}
+}
+
+func (m *Model) refilter() {
+	query, preds := splitFilter(m.filter)
+	var visible []string
+outer:
+	for _, p := range m.opts.Projects {
+		if m.hidden[p] && !m.showHidden {
+			continue
+		}
+		if st, ok := m.opts.Status[p]; ok || m.statuses == nil {
+			for _, pred := range preds {
+				if !pred(st) {
+					continue outer
+				}
+			}
+		}
+		visible = append(visible, p)
+	}
+	m.sel = 0
+	m.offset = 0
+
+	if m.filter != "" {
+		// Pinned matches first, each group in rank order
+		rows := filterList(visible, query, m.opts.Recent)
+		m.sortRows(rows)
+		sort.SliceStable(rows, func(i, j int) bool {
+			return m.favorite[rows[i].name] && !m.favorite[rows[j].name]
+		})
+		m.filtered = rows
+		if len(preds) > 0 {
+			return
+		}
+		if len(rows) == 0 && m.canCreate(query) {
+			m.filtered = append(m.filtered, row{name: query, create: true})
+		}
+		if remote, ok := m.remote(m.filter); ok {
+			m.filtered = append(m.filtered, row{name: git.RepoName(remote), clone: remote})
+		}
+		return
+	}
+
+	var pinned, recent []string
+	for _, p := range visible {
+		if m.favorite[p] {
+			pinned = append(pinned, p)
+		}
+	}
+	for _, p := range m.opts.Frecent {
+		if !m.favorite[p] && (m.showHidden || !m.hidden[p]) {
+			recent = append(recent, p)
+		}
+	}
+
+	m.filtered = nil
+	if len(pinned) > 0 || len(recent) > 0 {
+		m.filtered = append(m.filtered, section("pinned", pinned)...)
+		m.filtered = append(m.filtered, section("recent", recent)...)
+		m.filtered = append(m.filtered, row{heading: "all projects"})
+	}
+	all := filterList(visible, "", nil)
+	m.sortRows(all)
+	m.filtered = append(m.filtered, all...)
+	m.move(0)
+}
+
+// badges marks pinned projects, and hidden ones while they are revealed,
+// returning to base colour after. It also returns the badges' width.
+func (m *Model) badges(project, base string) (string, int) {
+	switch {
+	case m.favorite[project]:
+		return " " + ui.BrYell + "★" + base, 2
+	case m.hidden[project]:
+		return " " + ui.DkGray + "(hidden)" + base, 9
+	}
+	return "", 0
+}
+
+// info is what is shown beside a project: its type badge and git state
+func (m *Model) info(name string, now time.Time) string {
+	var parts []string
+	if badge := m.opts.Types[name].Badge(); badge != "" {
+		parts = append(parts, ui.DkGray+"["+badge+"]"+ui.Reset)
+	}
+	if _, ok := m.opts.Status[name]; !ok && m.statuses != nil {
+		parts = append(parts, ui.DkGray+"…"+ui.Reset)
+	} else if g := gitInfo(m.opts.Status[name], now); g != "" {
+		parts = append(parts, g)
+	}
+	return strings.Join(parts, " ")
+}
+
+// nameWidth is the width of the name column when git state is shown beside
+// it: the longest listed name plus its badges, up to a limit
+func (m *Model) nameWidth() int {
+	if len(m.opts.Status) == 0 && len(m.opts.Types) == 0 && m.statuses == nil {
+		return 0
+	}
+	w := 0
+	for _, r := range m.filtered {
+		_, bw := m.badges(r.name, "")
+		w = max(w, utf8.RuneCountInString(r.name)+bw)
+	}
+	return min(w, 40)
+}
+
+// remote resolves filter to a repository to clone, provided the checkout's
+// name is free
+func (m *Model) remote(filter string) (string, bool) {
+	if m.opts.Remote == nil || strings.Contains(filter, " ") {
+		return "", false
+	}
+	remote, ok := m.opts.Remote(filter)
+	if !ok || !m.isFree(git.RepoName(remote)) {
+		return "", false
+	}
+	return remote, true
+}
+
+// canCreate reports whether a project called name can be offered for
+// creation: it must be a plain directory name not already taken
+func (m *Model) canCreate(name string) bool {
+	return m.opts.CanCreate && m.isFree(name)
+}
+
+// isFree reports whether name can be used for a new project
+func (m *Model) isFree(name string) bool {
+	if !project.ValidName(name) {
+		return false
+	}
+	for _, p := range m.opts.Projects {
+		if strings.EqualFold(p, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// section returns a heading followed by names, or nothing when names is
+// empty
+func section(heading string, names []string) []row {
+	if len(names) == 0 {
+		return nil
+	}
+	rows := []row{{heading: heading}}
+	for _, n := range names {
+		rows = append(rows, row{name: n})
+	}
+	return rows
+}
+
+// position returns the selection's place among the selectable rows, and
+// how many there are
+func (m *Model) position() (n, total int) {
+	for i, r := range m.filtered {
+		if r.heading != "" {
+			continue
+		}
+		total++
+		if i <= m.sel {
+			n++
+		}
+	}
+	return n, total
+}
+
+// filterList fuzzy-matches query against items, best first, breaking ties
+// by recency. An empty query keeps every item in order.
+func filterList(items []string, query string, recent map[string]time.Time) []row {
+	if query == "" {
+		rows := make([]row, len(items))
+		for i, item := range items {
+			rows[i] = row{name: item}
+		}
+		return rows
+	}
+	matches := fuzzy.Find(query, items, func(i, j int) bool {
+		return recent[items[i]].After(recent[items[j]])
+	})
+	rows := make([]row, len(matches))
+	for i, mt := range matches {
+		rows[i] = row{name: items[mt.Index], matched: mt.Positions}
+	}
+	return rows
+}
+
+// highlight colours the matched runes of s, returning to base after each
+func highlight(s string, matched []int, base string) string {
+	if len(matched) == 0 {
+		return s
+	}
+	var b strings.Builder
+	next := 0
+	for i, r := range []rune(s) {
+		if next < len(matched) && matched[next] == i {
+			b.WriteString(ui.BrYell + string(r) + base)
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// also returns the marked projects after the first
+func (m *Model) also() []string {
+	if len(m.marked) < 2 {
+		return nil
+	}
+	return append([]string(nil), m.marked[1:]...)
+}
+
+// Result returns the choice once Update has reported the picker finished
+func (m *Model) Result() (Result, error) {
+	if m.phase != done {
+		return Result{}, ErrCancelled
+	}
+	return Result{Project: m.chosen, Profile: m.profile, Create: m.create, Clone: m.clone, Worktree: m.worktree, Also: m.also()}, nil
+}
+
+// View draws the picker for a terminal of the given size. Lines are
+// separated by "\n" and carry no trailing padding.
+func (m *Model) View(termWidth, height int) string {
+	switch m.phase {
+	case pickAccount:
+		return m.viewAccount()
+	case pickCreate:
+		return m.viewCreate()
+	}
+
+	m.maxShow = max(1, height-startY-4)
+	m.scroll()
+
+	var b strings.Builder
+	by := ""
+	if m.sort != sortName {
+		by = " by " + sortNames[m.sort]
+	}
+	if len(m.marked) > 0 {
+		by += fmt.Sprintf(" · %d marked", len(m.marked))
+	}
+	fmt.Fprintf(&b, "\n  %s%s%s %s· select project%s%s\n\n", ui.BrCyan, m.opts.Label, ui.Reset, ui.DkGray, by, ui.Reset)
+	if m.filter == "" {
+		fmt.Fprintf(&b, "  %s>%s %stype to filter...%s\n", ui.BrCyan, ui.Reset, ui.DkGray, ui.Reset)
+	} else {
+		fmt.Fprintf(&b, "  %s>%s %s%s%s\n", ui.BrCyan, ui.Reset, ui.BrWhite, m.filter, ui.Reset)
+	}
+	fmt.Fprintf(&b, "  %s─────────────────────────────────%s\n", ui.DkGray, ui.Reset)
+
+	width := m.nameWidth()
+	now := time.Now()
+	rows := make([]string, m.maxShow)
+	for i := range rows {
+		if idx := m.offset + i; idx < len(m.filtered) {
+			rows[i] = m.listRow(idx, width, now)
+		}
+	}
+	if pw := m.previewWidth(termWidth); pw > 0 {
+		rows = beside(rows, m.preview(m.selected()), termWidth-pw-3, pw)
+	}
+	for _, r := range rows {
+		b.WriteString(r + "\n")
+	}
+
+	if m.status != "" {
+		fmt.Fprintf(&b, "  %s%s%s", ui.BrYell, m.status, ui.Reset)
+	}
+	b.WriteString("\n")
+	if len(m.filtered) > m.maxShow {
+		n, total := m.position()
+		fmt.Fprintf(&b, "  %s↑↓%s navigate  %s(%d/%d)%s  %sesc%s quit",
+			ui.DkGray, ui.Reset, ui.DkGray, n, total, ui.Reset, ui.DkGray, ui.Reset)
+	} else {
+		fmt.Fprintf(&b, "  %s↑↓%s navigate  %senter%s select  %sesc%s quit",
+			ui.DkGray, ui.Reset, ui.DkGray, ui.Reset, ui.DkGray, ui.Reset)
+	}
+	fmt.Fprintf(&b, "  %stab%s sort", ui.DkGray, ui.Reset)
+	if m.opts.Preview != nil {
+		fmt.Fprintf(&b, "  %s^o%s preview", ui.DkGray, ui.Reset)
+	}
+	if m.opts.Multi {
+		fmt.Fprintf(&b, "  %sspace%s mark", ui.DkGray, ui.Reset)
+	}
+	if m.opts.Worktrees {
+		fmt.Fprintf(&b, "  %s^w%s worktree", ui.DkGray, ui.Reset)
+	}
+	if m.opts.OnMark != nil {
+		reveal := "show"
+		if m.showHidden {
+			reveal = "hide"
+		}
+		fmt.Fprintf(&b, "  %s^p%s pin  %s^x%s hide  %s^a%s %s hidden (%d)",
+			ui.DkGray, ui.Reset, ui.DkGray, ui.Reset, ui.DkGray, ui.Reset, reveal, m.hiddenCount())
+	}
+	return b.String()
+}
+
+// listRow draws the project or heading at idx, with the name column padded
+// to width when there is information beside it
+func (m *Model) listRow(idx, width int, now time.Time) string {
+	r := m.filtered[idx]
+	switch {
+	case r.heading != "":
+		return fmt.Sprintf("  %s%s%s", ui.DkGray, r.heading, ui.Reset)
+	case r.create && idx == m.sel:
+		return fmt.Sprintf("  %s%s + %screate %s %s", inverse, ui.BrGreen, ui.BrWhite, r.name, ui.Reset)
+	case r.create:
+		return fmt.Sprintf("    %s+ create %s%s", ui.DkGray, r.name, ui.Reset)
+	case r.clone != "" && idx == m.sel:
+		return fmt.Sprintf("  %s%s %s %sclone %s %s", inverse, ui.BrGreen, ui.Arrow, ui.BrWhite, r.clone, ui.Reset)
+	case r.clone != "":
+		return fmt.Sprintf("    %s%s clone %s%s", ui.DkGray, ui.Arrow, r.clone, ui.Reset)
+	}
+
+	base := ui.DkGray
+	if idx == m.sel {
+		base = ui.BrWhite
+	}
+	badge, bw := m.badges(r.name, base)
+	pad := ""
+	info := m.info(r.name, now)
+	if info != "" {
+		pad = strings.Repeat(" ", max(0, width-utf8.RuneCountInString(r.name)-bw))
+	}
+
+	// The selected row's bar is a column wider on each side
+	var row string
+	gap := "    "
+	marked := m.markIndex(r.name) >= 0
+	switch {
+	case idx == m.sel && marked:
+		row = fmt.Sprintf("  %s%s ● %s%s%s%s %s", inverse, ui.BrGreen, base, highlight(r.name, r.matched, base), badge, pad, ui.Reset)
+		gap = "  "
+	case idx == m.sel:
+		row = fmt.Sprintf("  %s%s > %s%s%s%s %s", inverse, ui.BrCyan, base, highlight(r.name, r.matched, base), badge, pad, ui.Reset)
+		gap = "  "
+	case marked:
+		row = fmt.Sprintf("  %s●%s %s%s%s%s%s", ui.BrGreen, ui.Reset, base, highlight(r.name, r.matched, base), badge, pad, ui.Reset)
+	default:
+		row = fmt.Sprintf("    %s%s%s%s%s", base, highlight(r.name, r.matched, base), badge, pad, ui.Reset)
+	}
+	if info != "" {
+		row += gap + info
+	}
+	return row
+}
+
+func (m *Model) viewCreate() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n  %s+%s %s%s%s\n\n", ui.BrGreen, ui.Reset, ui.BrWhite, m.chosen, ui.Reset)
+	fmt.Fprintf(&b, "  %s%s%s %s· new project template%s\n", ui.BrCyan, m.opts.Label, ui.Reset, ui.DkGray, ui.Reset)
+	fmt.Fprintf(&b, "  %s─────────────────────────────────%s\n", ui.DkGray, ui.Reset)
+	for i, name := range append([]string{"(empty)"}, m.opts.Templates...) {
+		if i == m.template {
+			fmt.Fprintf(&b, "  %s%s > %s%s %s\n", inverse, ui.BrCyan, ui.BrWhite, name, ui.Reset)
+		} else {
+			fmt.Fprintf(&b, "    %s%s%s\n", ui.DkGray, name, ui.Reset)
+		}
+	}
+
+	check := ui.BrRed + ui.Cross
+	if m.create.GitInit {
+		check = ui.BrGreen + ui.Check
+	}
+	fmt.Fprintf(&b, "\n  %s%s git init\n\n", check, ui.Reset)
+	fmt.Fprintf(&b, "  %s↑↓%s template  %sspace%s git init  %senter%s create  %sesc%s back",
+		ui.DkGray, ui.Reset, ui.DkGray, ui.Reset, ui.DkGray, ui.Reset, ui.DkGray, ui.Reset)
+	return b.String()
+}
+
+func (m *Model) viewAccount() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n  %s>%s %s%s%s\n\n", ui.BrGreen, ui.Reset, ui.BrWhite, m.chosen, ui.Reset)
+	fmt.Fprintf(&b, "  %s%s%s %s· select account%s\n", ui.BrCyan, m.opts.Label, ui.Reset, ui.DkGray, ui.Reset)
+	fmt.Fprintf(&b, "  %s─────────────────────────────────%s\n", ui.DkGray, ui.Reset)
+	for i, p := range m.opts.Profiles {
+		fmt.Fprintf(&b, "  %s%d%s  %s%s%s\n", ui.BrWhite, i+1, ui.Reset, ui.DkGray, p.Name, ui.Reset)
+	}
+	fmt.Fprintf(&b, "\n  %s>%s ", ui.BrCyan, ui.Reset)
+	return b.String()
+}
@@ -0,0 +1,459 @@
+package picker
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+func runes(s string) []Key {
+	var keys []Key
+	for _, r := range s {
+		keys = append(keys, Key{Type: KeyRune, Rune: r})
+	}
+	return keys
+}
+
+func press(m *Model, keys ...Key) bool {
+	for _, k := range keys {
+		if m.Update(k) {
+			return true
+		}
+	}
+	return false
+}
+
+var projects = []string{"api", "api-old", "api-v2", "web", "worker"}
+
+func TestModelFiltersAndSelects(t *testing.T) {
+	m := New(Options{Label: "cc", Projects: projects})
+
+	press(m, runes("API")...)
+	if got := m.filtered; len(got) != 3 {
+		t.Fatalf("expected 3 matches for API, got %v", got)
+	}
+	press(m, Key{Type: KeyDown}, Key{Type: KeyDown}, Key{Type: KeyDown}) // stops at the last match
+	press(m, Key{Type: KeyUp})
+	if !press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected enter to finish without profiles")
+	}
+
+	res, err := m.Result()
+	if err != nil || res.Project != "api-old" || res.Profile != nil {
+		t.Errorf("unexpected result %+v, %v", res, err)
+	}
+}
+
+func TestModelBackspaceWidensFilter(t *testing.T) {
+	m := New(Options{Projects: projects})
+	press(m, runes("wo")...)
+	press(m, Key{Type: KeyBackspace})
+	if len(m.filtered) != 2 || m.filter != "w" {
+		t.Errorf("expected w to match web and worker, got %v", m.filtered)
+	}
+	// Space is ignored, as in the original picker
+	press(m, Key{Type: KeyRune, Rune: ' '})
+	if m.filter != "w" {
+		t.Errorf("expected space to be ignored, got %q", m.filter)
+	}
+}
+
+func TestModelFuzzyRanking(t *testing.T) {
+	m := New(Options{Projects: []string{"gravel", "api-v2", "web"}})
+	press(m, runes("av")...)
+	if len(m.filtered) != 2 || m.filtered[0].name != "api-v2" {
+		t.Fatalf("expected the word-start match first, got %v", m.filtered)
+	}
+	if got := m.filtered[0].matched; len(got) != 2 || got[0] != 0 || got[1] != 4 {
+		t.Errorf("unexpected matched positions %v", got)
+	}
+}
+
+func TestModelRecencyBreaksTies(t *testing.T) {
+	now := time.Now()
+	recent := map[string]time.Time{"api-old": now, "api-v2": now.Add(-time.Hour)}
+	m := New(Options{Projects: projects, Recent: recent})
+	if m.filtered[0].name != "api" {
+		t.Errorf("expected an empty filter to keep the list order, got %v", m.filtered)
+	}
+	press(m, runes("api")...)
+	if got := m.filtered; got[0].name != "api-old" || got[1].name != "api-v2" || got[2].name != "api" {
+		t.Errorf("expected equal matches most recent first, got %v", got)
+	}
+}
+
+func TestModelRecentSection(t *testing.T) {
+	m := New(Options{Projects: projects, Frecent: []string{"worker", "api-v2"}})
+	if m.filtered[0].heading == "" || m.filtered[3].heading == "" || m.filtered[m.sel].name != "worker" {
+		t.Fatalf("expected a recent section selecting worker first, got %v", m.filtered)
+	}
+
+	// Moving skips the heading between the sections
+	press(m, Key{Type: KeyDown}, Key{Type: KeyDown})
+	if m.filtered[m.sel].name != "api" {
+		t.Errorf("expected down to skip the heading to api, got %q", m.filtered[m.sel].name)
+	}
+	press(m, Key{Type: KeyHome})
+	if m.sel != 1 || m.offset != 0 {
+		t.Errorf("expected home to select the first recent project with its heading visible, got sel %d offset %d", m.sel, m.offset)
+	}
+	view := m.View(80, 24)
+	if !strings.Contains(view, "recent") || !strings.Contains(view, "all projects") {
+		t.Errorf("expected both section headings, got:\n%s", view)
+	}
+
+	// Typing searches the full list only
+	press(m, runes("wor")...)
+	if len(m.filtered) != 1 || m.filtered[0].name != "worker" {
+		t.Errorf("expected one match without sections, got %v", m.filtered)
+	}
+	press(m, Key{Type: KeyBackspace}, Key{Type: KeyBackspace}, Key{Type: KeyBackspace})
+	if !press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected enter to pick the recent project")
+	}
+	if res, _ := m.Result(); res.Project != "worker" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestModelViewHighlightsMatches(t *testing.T) {
+	m := New(Options{Projects: []string{"web", "worker"}})
+	press(m, runes("wr")...)
+	view := m.View(80, 24)
+	if !strings.Contains(view, ui.BrYell+"w"+ui.BrWhite+"o"+ui.BrYell+"r"+ui.BrWhite+"ker") {
+		t.Errorf("expected the matched characters of the selected row highlighted, got:\n%q", view)
+	}
+
+	m = New(Options{Projects: []string{"web", "webhooks"}})
+	press(m, runes("wb")...)
+	if view := m.View(80, 24); !strings.Contains(view, ui.BrYell+"w"+ui.DkGray+"e"+ui.BrYell+"b"+ui.DkGray+"hooks") {
+		t.Errorf("expected the matched characters of other rows highlighted, got:\n%q", view)
+	}
+}
+
+func TestModelEnterWithNoMatches(t *testing.T) {
+	m := New(Options{Projects: projects})
+	press(m, runes("zzz")...)
+	if press(m, Key{Type: KeyEnter}) {
+		t.Errorf("enter with no matches should do nothing")
+	}
+}
+
+func TestModelCancel(t *testing.T) {
+	for _, k := range []Key{{Type: KeyEsc}, {Type: KeyCtrl, Rune: 'c'}} {
+		m := New(Options{Projects: projects})
+		if !press(m, k) {
+			t.Fatalf("expected %+v to close the picker", k)
+		}
+		if _, err := m.Result(); err != ErrCancelled {
+			t.Errorf("expected ErrCancelled, got %v", err)
+		}
+	}
+}
+
+func TestModelAccountStep(t *testing.T) {
+	profiles := []config.Profile{{Name: "work"}, {Name: "home"}}
+	m := New(Options{Label: "cc", Projects: projects, Profiles: profiles})
+
+	if press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected an account step with two profiles")
+	}
+	if !strings.Contains(m.View(80, 24), "select account") {
+		t.Errorf("expected the account list, got:\n%s", m.View(80, 24))
+	}
+	press(m, runes("3")...) // out of range
+	if !press(m, runes("2")...) {
+		t.Fatalf("expected 2 to pick the second account")
+	}
+	res, _ := m.Result()
+	if res.Project != "api" || res.Profile == nil || res.Profile.Name != "home" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestModelSingleProfileIsAutomatic(t *testing.T) {
+	m := New(Options{Projects: projects, Profiles: []config.Profile{{Name: "only"}}})
+	if !press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected a single profile to be chosen automatically")
+	}
+	if res, _ := m.Result(); res.Profile == nil || res.Profile.Name != "only" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestModelViewScrolls(t *testing.T) {
+	var many []string
+	for i := 0; i < 30; i++ {
+		many = append(many, fmt.Sprintf("p%02d", i))
+	}
+	m := New(Options{Label: "cc", Projects: many})
+
+	// 17 rows leave room for 10 projects
+	view := m.View(80, 17)
+	if !strings.Contains(view, "p09") || strings.Contains(view, "p10") {
+		t.Fatalf("expected the first 10 projects, got:\n%s", view)
+	}
+	if !strings.Contains(view, "(1/30)") {
+		t.Errorf("expected a position counter when the list scrolls")
+	}
+
+	press(m, Key{Type: KeyPgDown}, Key{Type: KeyDown})
+	view = m.View(80, 17)
+	if m.sel != 11 || !strings.Contains(view, "p11") || strings.Contains(view, "p01") {
+		t.Errorf("expected the view to follow the selection to p11, got sel %d:\n%s", m.sel, view)
+	}
+
+	press(m, Key{Type: KeyEnd})
+	if m.sel != 29 {
+		t.Errorf("expected end to select the last project, got %d", m.sel)
+	}
+}
+
+// marks records OnMark calls and fails when err is set
+type marks struct {
+	calls []string
+	err   error
+}
+
+func (mk *marks) save(project string, favorite, hidden bool) error {
+	if mk.err != nil {
+		return mk.err
+	}
+	mk.calls = append(mk.calls, fmt.Sprintf("%s:%v:%v", project, favorite, hidden))
+	return nil
+}
+
+func names(m *Model) []string {
+	var out []string
+	for _, r := range m.filtered {
+		if r.heading != "" {
+			out = append(out, "["+r.heading+"]")
+		} else {
+			out = append(out, r.name)
+		}
+	}
+	return out
+}
+
+func TestModelPinnedAndHidden(t *testing.T) {
+	m := New(Options{Projects: projects, Favorites: []string{"web"}, Hidden: []string{"api-old"}})
+	want := "[pinned] web [all projects] api api-v2 web worker"
+	if got := strings.Join(names(m), " "); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Pinned matches rank first while filtering, hidden ones are left out
+	press(m, runes("w")...)
+	if got := strings.Join(names(m), " "); got != "web worker" {
+		t.Errorf("unexpected filtered list %q", got)
+	}
+	press(m, Key{Type: KeyBackspace}, Key{Type: KeyCtrl, Rune: 'a'})
+	if got := strings.Join(names(m), " "); got != "[pinned] web [all projects] api api-old api-v2 web worker" {
+		t.Errorf("expected ^a to reveal hidden projects, got %q", got)
+	}
+	if !strings.Contains(m.View(80, 24), "(hidden)") {
+		t.Errorf("expected revealed projects to be marked hidden")
+	}
+}
+
+func TestModelMarksPersist(t *testing.T) {
+	mk := &marks{}
+	m := New(Options{Projects: projects, OnMark: mk.save})
+
+	// Pin worker, then hide api
+	press(m, runes("work")...)
+	press(m, Key{Type: KeyCtrl, Rune: 'p'})
+	for range "work" {
+		press(m, Key{Type: KeyBackspace})
+	}
+	if m.selected() != "worker" || !strings.HasPrefix(strings.Join(names(m), " "), "[pinned] worker") {
+		t.Errorf("expected worker pinned and selected, got %v with %q selected", names(m), m.selected())
+	}
+	press(m, Key{Type: KeyDown}, Key{Type: KeyCtrl, Rune: 'x'})
+	if got := strings.Join(names(m), " "); got != "[pinned] worker [all projects] api-old api-v2 web worker" {
+		t.Errorf("expected api hidden, got %q", got)
+	}
+	if m.selected() != "api-old" {
+		t.Errorf("expected the next project selected after hiding, got %q", m.selected())
+	}
+
+	// Hiding a pinned project unpins it
+	press(m, Key{Type: KeyHome}, Key{Type: KeyCtrl, Rune: 'x'})
+	want := []string{"worker:true:false", "api:false:true", "worker:false:true"}
+	if !reflect.DeepEqual(mk.calls, want) {
+		t.Errorf("got calls %v, want %v", mk.calls, want)
+	}
+
+	mk.err = fmt.Errorf("config is read-only")
+	press(m, Key{Type: KeyCtrl, Rune: 'p'})
+	if m.favorite["api-old"] || !strings.Contains(m.View(80, 24), "config is read-only") {
+		t.Errorf("expected a failed save to leave the project unpinned and show the error")
+	}
+}
+
+func TestModelMarksNeedOnMark(t *testing.T) {
+	m := New(Options{Projects: projects})
+	press(m, Key{Type: KeyCtrl, Rune: 'x'})
+	if len(m.filtered) != len(projects) || strings.Contains(m.View(80, 24), "^x") {
+		t.Errorf("expected hiding to be unavailable without OnMark")
+	}
+}
+
+func TestModelCreateProject(t *testing.T) {
+	m := New(Options{Label: "cc", Projects: projects, CanCreate: true, Templates: []string{"go", "node"}})
+
+	press(m, runes("web")...)
+	if len(m.filtered) != 1 || m.filtered[0].create {
+		t.Fatalf("expected no create action while something matches, got %v", m.filtered)
+	}
+	press(m, Key{Type: KeyBackspace}, Key{Type: KeyBackspace}, Key{Type: KeyBackspace})
+	press(m, runes("svc")...)
+	if len(m.filtered) != 1 || !m.filtered[0].create || m.selected() != "" {
+		t.Fatalf("expected only the create action, got %v", m.filtered)
+	}
+	if !strings.Contains(m.View(80, 24), "create svc") {
+		t.Errorf("expected the create action to be drawn")
+	}
+
+	// Esc goes back to the list rather than closing
+	press(m, Key{Type: KeyEnter})
+	if press(m, Key{Type: KeyEsc}) || m.phase != pickProject {
+		t.Fatalf("expected esc to return to the list")
+	}
+
+	press(m, Key{Type: KeyEnter})
+	view := m.View(80, 24)
+	if !strings.Contains(view, "(empty)") || !strings.Contains(view, "node") {
+		t.Errorf("expected the templates, got:\n%s", view)
+	}
+	press(m, Key{Type: KeyDown}, Key{Type: KeyDown}, Key{Type: KeyDown}, Key{Type: KeyRune, Rune: ' '})
+	if !press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected enter to finish")
+	}
+	res, err := m.Result()
+	if err != nil || res.Project != "svc" || res.Create == nil || res.Create.Template != "node" || res.Create.GitInit {
+		t.Errorf("unexpected result %+v (create %+v), %v", res, res.Create, err)
+	}
+}
+
+func TestModelCreateNeedsNewValidName(t *testing.T) {
+	// A path, a filter using prefixes, and an existing name in another case
+	for _, filter := range []string{"a/b", ":dirty svc", "API"} {
+		m := New(Options{Projects: []string{"api"}, CanCreate: true})
+		m.filter = filter
+		m.refilter()
+		for _, r := range m.filtered {
+			if r.create {
+				t.Errorf("expected no create action for %q", filter)
+			}
+		}
+	}
+
+	m := New(Options{Projects: projects})
+	press(m, runes("svc")...)
+	if len(m.filtered) != 0 {
+		t.Errorf("expected no create action unless enabled")
+	}
+}
+
+func TestModelCloneRemote(t *testing.T) {
+	remote := func(ref string) (string, bool) {
+		if path, ok := strings.CutPrefix(ref, "gh:"); ok {
+			return "https://github.com/" + path + ".git", true
+		}
+		return "", false
+	}
+	m := New(Options{Label: "cc", Projects: projects, Remote: remote})
+
+	press(m, runes("gh:team/api")...)
+	for _, r := range m.filtered {
+		if r.clone != "" {
+			t.Fatalf("expected no clone action onto an existing project, got %v", m.filtered)
+		}
+	}
+
+	m.filter = "gh:team/svc"
+	m.refilter()
+	if len(m.filtered) != 1 || m.filtered[0].clone == "" || m.selected() != "" {
+		t.Fatalf("expected only the clone action, got %v", m.filtered)
+	}
+	if !strings.Contains(m.View(80, 24), "clone https://github.com/team/svc.git") {
+		t.Errorf("expected the clone action to be drawn")
+	}
+	if !press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected enter to finish")
+	}
+	res, err := m.Result()
+	if err != nil || res.Project != "svc" || res.Clone != "https://github.com/team/svc.git" {
+		t.Errorf("unexpected result %+v, %v", res, err)
+	}
+}
+
+func TestModelWorktree(t *testing.T) {
+	m := New(Options{Projects: projects})
+	press(m, Key{Type: KeyCtrl, Rune: 'w'})
+	if m.phase != pickProject || strings.Contains(m.View(80, 24), "^w") {
+		t.Fatalf("expected worktrees to be unavailable unless enabled")
+	}
+
+	m = New(Options{Projects: projects, Worktrees: true})
+	press(m, runes("web")...)
+	if !press(m, Key{Type: KeyCtrl, Rune: 'w'}) {
+		t.Fatalf("expected ^w to finish")
+	}
+	res, err := m.Result()
+	if err != nil || res.Project != "web" || !res.Worktree {
+		t.Errorf("unexpected result %+v, %v", res, err)
+	}
+}
+
+func TestModelMarksSeveral(t *testing.T) {
+	m := New(Options{Projects: projects, Multi: true})
+	press(m, runes("w")...)
+	// Marking moves down, so the two w projects are marked in turn
+	press(m, Key{Type: KeyRune, Rune: ' '}, Key{Type: KeyRune, Rune: ' '})
+	press(m, Key{Type: KeyBackspace})
+	press(m, runes("api-v")...)
+	press(m, Key{Type: KeyRune, Rune: ' '})
+	if view := m.View(80, 24); !strings.Contains(view, "3 marked") || !strings.Contains(view, "space") {
+		t.Errorf("expected the marks to be counted, got:\n%s", view)
+	}
+
+	// Marking again unmarks
+	press(m, Key{Type: KeyRune, Rune: ' '}, Key{Type: KeyRune, Rune: ' '})
+	if !press(m, Key{Type: KeyEnter}) {
+		t.Fatalf("expected enter to finish")
+	}
+	res, err := m.Result()
+	if err != nil || res.Project != "web" || !reflect.DeepEqual(res.Also, []string{"worker", "api-v2"}) {
+		t.Errorf("unexpected result %+v, %v", res, err)
+	}
+}
+
+func TestModelMarksNeedMulti(t *testing.T) {
+	m := New(Options{Projects: projects})
+	press(m, Key{Type: KeyRune, Rune: ' '})
+	if len(m.marked) != 0 || m.filter != "" {
+		t.Errorf("expected space to do nothing, got marks %v and filter %q", m.marked, m.filter)
+	}
+
+	// After a filter prefix a space separates it from the search
+	m = New(Options{Projects: projects, Multi: true})
+	press(m, runes(":git")...)
+	press(m, Key{Type: KeyRune, Rune: ' '})
+	if len(m.marked) != 0 || m.filter != ":git " {
+		t.Errorf("expected the space to go to the filter, got marks %v and filter %q", m.marked, m.filter)
+	}
+}
+
+func TestModelStartsOnSelected(t *testing.T) {
+	m := New(Options{Projects: projects, Selected: "web"})
+	if m.selected() != "web" {
+		t.Errorf("expected web to be highlighted, got %q", m.selected())
+	}
+}
@@ -0,0 +1,28 @@
+package picker
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bcmister/cc/internal/ui"
+)
+
+// preLaunch runs each command through the shell in dir before the tool
+// starts. A failing command is reported and the rest still run, so a broken
+// hook never keeps the tool from starting.
+func preLaunch(w io.Writer, cmds []string, dir string, env map[string]string) {
+	for _, line := range cmds {
+		fmt.Fprintf(w, "  %s%s %s%s\n", ui.DkGray, ui.Arrow, line, ui.Reset)
+
+		cmd := shell(line)
+		cmd.Dir = dir
+		cmd.Env = environWith(env)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(w, "  %s%s %s: %v%s\n", ui.BrYell, ui.Cross, line, err, ui.Reset)
+		}
+	}
+}
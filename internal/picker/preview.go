@@ -0,0 +1,214 @@
+package picker
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+// MinPreviewWidth is the narrowest terminal that still shows the preview
+const MinPreviewWidth = 100
+
+const (
+	readmeLines    = 12
+	previewCommits = 5
+	logTimeout     = time.Second
+)
+
+// agentFiles are the instruction files whose presence the preview reports
+var agentFiles = []string{"CLAUDE.md", "AGENTS.md"}
+
+// LoadPreview describes the project in dir: which agent instruction files
+// it has, the start of its README and its latest commits
+func LoadPreview(dir string) []string {
+	var parts []string
+	for _, f := range agentFiles {
+		mark := ui.BrRed + ui.Cross + ui.Reset
+		if _, err := os.Stat(filepath.Join(dir, f)); err == nil {
+			mark = ui.BrGreen + ui.Check + ui.Reset
+		}
+		parts = append(parts, f+" "+mark)
+	}
+	lines := []string{strings.Join(parts, "   ")}
+
+	if name, text := readme(dir); name != "" {
+		lines = append(lines, "", ui.DkGray+name+ui.Reset)
+		lines = append(lines, text...)
+	}
+
+	if git.IsRepo(dir) {
+		ctx, cancel := context.WithTimeout(context.Background(), logTimeout)
+		defer cancel()
+		if commits, err := git.Log(ctx, dir, previewCommits); err == nil && len(commits) > 0 {
+			lines = append(lines, "", ui.DkGray+"commits"+ui.Reset)
+			for _, c := range commits {
+				// Colour the hash
+				if i := strings.IndexByte(c, ' '); i > 0 {
+					c = ui.BrYell + c[:i] + ui.Reset + c[i:]
+				}
+				lines = append(lines, c)
+			}
+		}
+	}
+	return lines
+}
+
+// readme returns the name of dir's README and its first lines, skipping
+// leading blank ones
+func readme(dir string) (string, []string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(strings.ToLower(e.Name()), "readme") {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return "", nil
+		}
+		defer f.Close()
+
+		var lines []string
+		sc := bufio.NewScanner(f)
+		for sc.Scan() && len(lines) < readmeLines {
+			line := strings.TrimRight(strings.ReplaceAll(sc.Text(), "\t", "    "), " \r")
+			if line == "" && len(lines) == 0 {
+				continue
+			}
+			lines = append(lines, stripEscapes(line))
+		}
+		return e.Name(), lines
+	}
+	return "", nil
+}
+
+// previewWidth is the width of the preview pane, or 0 when it is off or the
+// terminal is too narrow
+func (m *Model) previewWidth(termWidth int) int {
+	if m.opts.Preview == nil || m.hidePreview || termWidth < MinPreviewWidth || m.selected() == "" {
+		return 0
+	}
+	return termWidth * 2 / 5
+}
+
+// loadedPreview is a preview loaded in the background
+type loadedPreview struct {
+	project string
+	lines   []string
+}
+
+// preview returns the cached preview of project, or a placeholder until it
+// is loaded
+func (m *Model) preview(project string) []string {
+	if lines, ok := m.previews[project]; ok {
+		return lines
+	}
+	m.wanted = project
+	return []string{ui.DkGray + "loading…" + ui.Reset}
+}
+
+// nextPreview returns the project whose preview the last View wanted, if
+// it is not already loading, and notes it as loading
+func (m *Model) nextPreview() (string, bool) {
+	p := m.wanted
+	m.wanted = ""
+	if _, ok := m.previews[p]; ok || p == "" || m.loading[p] {
+		return "", false
+	}
+	if m.loading == nil {
+		m.loading = map[string]bool{}
+	}
+	m.loading[p] = true
+	return p, true
+}
+
+// setPreview stores the loaded preview of project
+func (m *Model) setPreview(p loadedPreview) {
+	if m.previews == nil {
+		m.previews = map[string][]string{}
+	}
+	m.previews[p.project] = p.lines
+	delete(m.loading, p.project)
+}
+
+// beside lays right out to the right of left, clipping left rows to
+// leftWidth and right lines to rightWidth
+func beside(left, right []string, leftWidth, rightWidth int) []string {
+	rows := make([]string, len(left))
+	for i, l := range left {
+		l = clip(l, leftWidth)
+		rows[i] = l + strings.Repeat(" ", leftWidth-visibleWidth(l)) + " " + ui.DkGray + "│" + ui.Reset
+		if i < len(right) && right[i] != "" {
+			rows[i] += " " + clip(right[i], rightWidth)
+		}
+	}
+	return rows
+}
+
+// visibleWidth counts the runes of s outside escape sequences
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(stripEscapes(s))
+}
+
+// clip cuts s to w visible runes, keeping its escape sequences and
+// resetting colours when anything was cut
+func clip(s string, w int) string {
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			j := escapeEnd(s, i)
+			b.WriteString(s[i:j])
+			i = j
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if n == w {
+			return b.String() + ui.Reset
+		}
+		b.WriteRune(r)
+		n++
+		i += size
+	}
+	return b.String()
+}
+
+// stripEscapes removes the escape sequences from s
+func stripEscapes(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i = escapeEnd(s, i)
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// escapeEnd returns the index just past the CSI sequence starting at s[i],
+// or past the lone ESC when it is not one
+func escapeEnd(s string, i int) int {
+	if i+1 >= len(s) || s[i+1] != '[' {
+		return i + 1
+	}
+	for j := i + 2; j < len(s); j++ {
+		if s[j] >= 0x40 && s[j] <= 0x7e {
+			return j + 1
+		}
+	}
+	return len(s)
+}
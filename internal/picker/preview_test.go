@@ -0,0 +1,102 @@
+package picker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bcmister/cc/internal/ui"
+)
+
+func TestLoadPreview(t *testing.T) {
+	dir := t.TempDir()
+	readme := "\n\n# api\n\nThe \x1b[31mAPI\x1b[0m server\n"
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "CLAUDE.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := LoadPreview(dir)
+	if !strings.Contains(lines[0], "CLAUDE.md "+ui.BrGreen+ui.Check) || !strings.Contains(lines[0], "AGENTS.md "+ui.BrRed+ui.Cross) {
+		t.Errorf("unexpected agent files line %q", lines[0])
+	}
+	got := strings.Join(lines[2:], "\n")
+	if !strings.Contains(got, "README.md") || !strings.Contains(got, "# api\n\nThe API server") {
+		t.Errorf("expected the README without leading blanks or escapes, got %q", got)
+	}
+}
+
+func TestClip(t *testing.T) {
+	s := ui.BrCyan + "main" + ui.Reset + " ok"
+	if got := clip(s, 6); got != ui.BrCyan+"main"+ui.Reset+" o"+ui.Reset {
+		t.Errorf("unexpected clip %q", got)
+	}
+	if got := clip(s, 20); got != s {
+		t.Errorf("expected a short string unchanged, got %q", got)
+	}
+	if visibleWidth(s) != 7 {
+		t.Errorf("expected 7 visible runes, got %d", visibleWidth(s))
+	}
+}
+
+// loadPreviews loads the previews m asks for, as run does in the background
+func loadPreviews(m *Model) {
+	for {
+		p, ok := m.nextPreview()
+		if !ok {
+			return
+		}
+		m.setPreview(loadedPreview{p, m.opts.Preview(p)})
+	}
+}
+
+func TestModelPreview(t *testing.T) {
+	calls := map[string]int{}
+	m := New(Options{Projects: projects, Preview: func(p string) []string {
+		calls[p]++
+		return []string{"about " + p}
+	}})
+
+	if view := m.View(80, 24); strings.Contains(view, "about") {
+		t.Errorf("expected no preview in a narrow terminal")
+	}
+	loadPreviews(m)
+	if len(calls) != 0 {
+		t.Errorf("expected the preview not to be loaded while hidden, got %v", calls)
+	}
+
+	// A placeholder shows until the preview is loaded, which is asked for once
+	if view := m.View(120, 24); !strings.Contains(view, "loading…") {
+		t.Errorf("expected a placeholder before the preview is loaded, got:\n%s", view)
+	}
+	if p, ok := m.nextPreview(); !ok || p != "api" {
+		t.Fatalf("expected the api preview to be wanted, got %q", p)
+	}
+	m.View(120, 24)
+	if _, ok := m.nextPreview(); ok {
+		t.Errorf("expected a preview being loaded not to be asked for again")
+	}
+	m.setPreview(loadedPreview{"api", m.opts.Preview("api")})
+
+	view := m.View(120, 24)
+	if !strings.Contains(view, "│"+ui.Reset+" about api") {
+		t.Errorf("expected the preview beside the list, got:\n%s", view)
+	}
+	press(m, Key{Type: KeyDown})
+	m.View(120, 24)
+	loadPreviews(m)
+	press(m, Key{Type: KeyUp})
+	m.View(120, 24)
+	loadPreviews(m)
+	if calls["api"] != 1 || calls["api-old"] != 1 || len(calls) != 2 {
+		t.Errorf("expected each highlighted project loaded once, got %v", calls)
+	}
+
+	press(m, Key{Type: KeyCtrl, Rune: 'o'})
+	if strings.Contains(m.View(120, 24), "about") {
+		t.Errorf("expected ^o to hide the preview")
+	}
+}
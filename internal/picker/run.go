@@ -0,0 +1,107 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pollInterval is how often the picker looks for work finished in the
+// background while waiting for a key
+const pollInterval = 50 * time.Millisecond
+
+const (
+	hideCursor = "\x1b[?25l"
+	showCursor = "\x1b[?25h"
+	clearLine  = "\x1b[K"
+	clearBelow = "\x1b[J"
+	home       = "\x1b[H"
+)
+
+// Run shows the picker on t until a project is chosen or the picker is
+// closed, returning ErrCancelled for the latter
+func Run(t Terminal, opts Options) (Result, error) {
+	return run(t, New(opts))
+}
+
+// run shows m on t until it finishes, redrawing as statuses and previews
+// loaded in the background come in
+func run(t Terminal, m *Model) (Result, error) {
+	restore, err := t.Raw()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer restore()
+
+	fmt.Fprint(t, "\x1b[2J"+home+hideCursor)
+	defer fmt.Fprint(t, showCursor+home+clearBelow)
+
+	// Previews still loading when the picker closes are dropped
+	done := make(chan struct{})
+	defer close(done)
+	previews := make(chan loadedPreview)
+
+	buf := make([]byte, 256)
+	for {
+		draw(t, m)
+		if p, ok := m.nextPreview(); ok {
+			load := m.opts.Preview
+			go func() {
+				select {
+				case previews <- loadedPreview{p, load(p)}:
+				case <-done:
+				}
+			}()
+		}
+		if ready, err := await(t, m, previews); err != nil {
+			return Result{}, fmt.Errorf("failed to read input: %w", err)
+		} else if !ready {
+			continue
+		}
+		n, err := t.Read(buf)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to read input: %w", err)
+		}
+		for _, k := range ParseKeys(buf[:n]) {
+			if m.Update(k) {
+				return m.Result()
+			}
+		}
+	}
+}
+
+// await waits for a key, reporting false instead when something finished
+// in the background first and m needs redrawing. Terminals that cannot wait
+// go straight to reading.
+func await(t Terminal, m *Model, previews <-chan loadedPreview) (bool, error) {
+	w, canWait := t.(waiter)
+	for {
+		select {
+		case ps, ok := <-m.statuses:
+			if !ok {
+				m.statusesDone()
+			} else {
+				m.setStatus(ps)
+			}
+			return false, nil
+		case p := <-previews:
+			m.setPreview(p)
+			return false, nil
+		default:
+		}
+		if !canWait || m.statuses == nil && len(m.loading) == 0 {
+			return true, nil
+		}
+		if ready, err := w.Wait(pollInterval); ready || err != nil {
+			return ready, err
+		}
+	}
+}
+
+// draw repaints the whole picker in place, clearing what the last frame
+// left behind rather than the screen, so it does not flicker
+func draw(t Terminal, m *Model) {
+	width, height := t.Size()
+	lines := strings.Split(m.View(width, height), "\n")
+	fmt.Fprint(t, home+strings.Join(lines, clearLine+"\n")+clearBelow)
+}
@@ -0,0 +1,115 @@
+package picker
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcmister/cc/internal/git"
+)
+
+// virtualTerminal feeds scripted reads to the picker and records its output
+type virtualTerminal struct {
+	input    []string
+	out      bytes.Buffer
+	width    int // 80 when zero
+	height   int
+	raw      bool
+	restored bool
+	idle     int // waits that see no input before it is read
+}
+
+func (v *virtualTerminal) Wait(d time.Duration) (bool, error) {
+	if v.idle > 0 {
+		v.idle--
+		time.Sleep(d)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (v *virtualTerminal) Read(p []byte) (int, error) {
+	if len(v.input) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, v.input[0])
+	v.input = v.input[1:]
+	return n, nil
+}
+
+func (v *virtualTerminal) Write(p []byte) (int, error) { return v.out.Write(p) }
+func (v *virtualTerminal) Size() (int, int)            { return max(v.width, 80), v.height }
+
+func (v *virtualTerminal) Raw() (func() error, error) {
+	v.raw = true
+	return func() error { v.restored = true; return nil }, nil
+}
+
+func TestRun(t *testing.T) {
+	vt := &virtualTerminal{height: 24, input: []string{"w", "o", "\x1b[B", "\x1b[A", "\r"}}
+	res, err := Run(vt, Options{Label: "cx", Projects: projects})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Project != "worker" {
+		t.Errorf("expected worker, got %q", res.Project)
+	}
+	if !vt.raw || !vt.restored {
+		t.Errorf("expected raw mode to be entered and restored")
+	}
+
+	out := vt.out.String()
+	if !strings.Contains(out, "cx") || !strings.Contains(out, "worker") {
+		t.Errorf("expected the picker to be drawn, got %q", out)
+	}
+	if !strings.HasSuffix(out, showCursor+home+clearBelow) {
+		t.Errorf("expected the cursor to be restored and the picker cleared")
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	vt := &virtualTerminal{height: 24, input: []string{"a", "\x1b"}}
+	if _, err := Run(vt, Options{Projects: projects}); !errors.Is(err, ErrCancelled) {
+		t.Errorf("expected ErrCancelled, got %v", err)
+	}
+}
+
+func TestRunInputClosed(t *testing.T) {
+	vt := &virtualTerminal{height: 24, input: []string{"a"}}
+	if _, err := Run(vt, Options{Projects: projects}); err == nil || errors.Is(err, ErrCancelled) {
+		t.Errorf("expected a read error, got %v", err)
+	}
+	if !vt.restored {
+		t.Errorf("expected raw mode to be restored after an error")
+	}
+}
+
+func TestRunTakesLateStatuses(t *testing.T) {
+	statuses := make(chan git.ProjectStatus, 1)
+	statuses <- git.ProjectStatus{Project: "web", Status: git.Status{Repo: true, Branch: "feature"}}
+	close(statuses)
+
+	vt := &virtualTerminal{height: 24, input: []string{"\r"}}
+	if _, err := Run(vt, Options{Projects: projects, Statuses: statuses}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(vt.out.String(), "feature") {
+		t.Errorf("expected the late status to be drawn")
+	}
+}
+
+func TestRunLoadsPreviewsInTheBackground(t *testing.T) {
+	preview := func(p string) []string { return []string{"about " + p} }
+	vt := &virtualTerminal{width: 120, height: 24, idle: 100, input: []string{"\r"}}
+	if _, err := Run(vt, Options{Projects: projects, Preview: preview}); err != nil {
+		t.Fatal(err)
+	}
+	out := vt.out.String()
+	loading, loaded := strings.Index(out, "loading…"), strings.Index(out, "about api")
+	if loading < 0 || loaded < loading {
+		t.Errorf("expected a placeholder, then the preview drawn without a key")
+	}
+}
@@ -0,0 +1,362 @@
+package picker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/history"
+	"github.com/bcmister/cc/internal/project"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+// Placeholders in Session.Title, replaced once the project and account are
+// known
+const (
+	ProjectPlaceholder = "__CC_PROJECT__"
+	ProfilePlaceholder = "__CC_PROFILE__"
+)
+
+// StatusBudget is how long the picker waits for git status before showing
+// the list; repositories slower than that get theirs once it is read
+const StatusBudget = 400 * time.Millisecond
+
+// CdFileEnv names the variable shell integration passes a file in; the
+// directory the tool starts in is written there so the shell can cd to it
+const CdFileEnv = "CC_CD_FILE"
+
+// statusWorkers is how many git commands run at once
+const statusWorkers = 8
+
+// Session is one picker run: where to look for projects and what to start
+// in the chosen one
+type Session struct {
+	Dir      string // projects root
+	Command  string // e.g. "claude --dangerously-skip-permissions"
+	Label    string // e.g. "cc" or "cx"
+	Title    string // terminal title once a project is chosen; empty leaves it alone
+	Profiles []config.Profile
+	// Open starts each of projects in a window of its own beside this one,
+	// with res's account and worktree choice. A nil Open turns off marking
+	// several projects.
+	Open func(res Result, projects []string) error
+	// CdFile receives the chosen project's directory; empty writes nothing
+	CdFile string
+	// NoLaunch stops once the project is chosen, starting no tool
+	NoLaunch bool
+	// Loop waits for the tool and, once it exits, offers to go back to the
+	// picker, restart it or switch account rather than closing the window
+	Loop bool
+}
+
+// Pick runs the picker for s on t and then replaces the process with the
+// session's command in the chosen project. Closing the picker returns nil.
+func Pick(t Terminal, s Session) error {
+	return pickFrom(t, s, "")
+}
+
+// pickFrom runs the picker with selected highlighted, then starts the
+// chosen project. In loop mode it comes back until the window is closed.
+func pickFrom(t Terminal, s Session, selected string) error {
+	for {
+		res, err := choose(t, s, selected)
+		if errors.Is(err, ErrCancelled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !s.Loop || s.NoLaunch {
+			return Start(t, s, res)
+		}
+		if back, err := repeat(t, s, res); !back || err != nil {
+			return err
+		}
+		selected = res.Project
+	}
+}
+
+// choose runs the picker once and sets up the chosen project: created,
+// cloned, and with the other marked projects opened beside it
+func choose(t Terminal, s Session, selected string) (Result, error) {
+	projects, err := ListProjects(s.Dir)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// A missing or unreadable history only costs the Recent section
+	h, err := history.Load("")
+	if err != nil {
+		h = &history.History{}
+	}
+	frecent, recent := recentProjects(h, s.Dir, projects, time.Now())
+
+	// Statuses still unread after the budget arrive while the picker is open
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statuses := git.Stream(ctx, s.Dir, projects, statusWorkers)
+	status := firstStatuses(statuses, StatusBudget)
+
+	opts := Options{
+		Label:    s.Label,
+		Projects: projects,
+		Profiles: s.Profiles,
+		Recent:   recent,
+		Frecent:  frecent,
+		Status:   status,
+		Statuses: statuses,
+		Types:    project.DetectAll(s.Dir, projects),
+		Preview: func(p string) []string {
+			return LoadPreview(filepath.Join(s.Dir, p))
+		},
+		Templates: project.ListTemplates(project.TemplatesDir()),
+		CanCreate: true,
+		Worktrees: true,
+		Multi:     s.Open != nil && !s.NoLaunch,
+		Selected:  selected,
+	}
+	// Without a config there is nowhere to keep pins
+	cfg, err := config.Load("")
+	if err == nil {
+		opts.Favorites = cfg.Favorites
+		opts.Hidden = cfg.Hidden
+		opts.OnMark = saveMark
+	} else {
+		cfg = &config.Config{}
+	}
+	opts.Remote = func(ref string) (string, bool) {
+		return git.Remote(ref, cfg.Clone.Shorthands, cfg.Clone.DefaultOrg)
+	}
+
+	res, err := Run(t, opts)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if res.Create != nil {
+		co := project.CreateOptions{GitInit: res.Create.GitInit}
+		if res.Create.Template != "" {
+			co.Template = filepath.Join(project.TemplatesDir(), res.Create.Template)
+		}
+		if _, err := project.Create(context.Background(), s.Dir, res.Project, co); err != nil {
+			return Result{}, err
+		}
+	}
+	if res.Clone != "" {
+		fmt.Fprintf(t, "\n  %s%s%s cloning %s%s\n\n", ui.BrCyan, ui.Arrow, ui.DkGray, res.Clone, ui.Reset)
+		if err := git.Clone(context.Background(), res.Clone, filepath.Join(s.Dir, res.Project), t); err != nil {
+			return Result{}, err
+		}
+	}
+	if len(res.Also) > 0 {
+		fmt.Fprintf(t, "\n  %s%s%s opening %s%s\n", ui.BrCyan, ui.Arrow, ui.DkGray, strings.Join(res.Also, ", "), ui.Reset)
+		if err := s.Open(res, res.Also); err != nil {
+			fmt.Fprintf(t, "  %s%s%s\n", ui.BrYell, err, ui.Reset)
+		}
+	}
+	return res, nil
+}
+
+// Start records res.Project as picked and replaces the process with the
+// session's command in it, or the tool an override picks for it. With
+// s.NoLaunch it returns once the project is recorded; with s.Loop it goes
+// on to the picker when asked to once the tool exits.
+func Start(t Terminal, s Session, res Result) error {
+	if s.Loop && !s.NoLaunch {
+		if back, err := repeat(t, s, res); !back || err != nil {
+			return err
+		}
+		return pickFrom(t, s, res.Project)
+	}
+
+	l := prepare(t, s, res)
+	if s.NoLaunch {
+		return nil
+	}
+	preLaunch(t, l.preLaunch, l.dir, l.env)
+	return Exec(l.command, l.dir, l.env, l.unlock)
+}
+
+// launch is the tool ready to start in a project
+type launch struct {
+	command   string
+	dir       string
+	env       map[string]string
+	preLaunch []string
+	unlock    func() // frees the worktree dir is in, if it was locked
+}
+
+// prepare records res.Project as picked and works out how to start the
+// tool in it, making its worktree when one is wanted
+func prepare(t Terminal, s Session, res Result) launch {
+	// Without a config there are no overrides
+	cfg, err := config.Load("")
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	if err := history.Add("", filepath.Join(s.Dir, res.Project), time.Now()); err != nil {
+		fmt.Fprintf(t, "\n  %s%s%s", ui.BrYell, err, ui.Reset)
+	}
+
+	if s.Title != "" && !s.NoLaunch {
+		fmt.Fprintf(t, "\x1b]0;%s\x07", title(s.Title, res))
+	}
+	fmt.Fprintf(t, "\n  %s>%s %s%s%s\n\n", ui.BrGreen, ui.Reset, ui.BrWhite, res.Project, ui.Reset)
+
+	dir := filepath.Join(s.Dir, res.Project)
+	o := cfg.OverrideFor(res.Project, string(project.Detect(dir).Type))
+	command := s.Command
+	switch {
+	case res.Tool != "":
+		command = config.CommandFor(res.Tool)
+	case o.Tool != "":
+		command = config.CommandFor(o.Tool)
+	}
+	var unlock func()
+	if res.Worktree || o.Worktree {
+		// Without its own tree the tool still starts, in the shared one
+		if wt, err := newWorktree(dir, filepath.Join(cfg.WorktreesDir(), res.Project)); err != nil {
+			fmt.Fprintf(t, "  %s%s%s\n\n", ui.BrYell, err, ui.Reset)
+		} else {
+			fmt.Fprintf(t, "  %s%s worktree %s on %s%s\n\n", ui.DkGray, ui.Arrow, wt.Path, wt.Branch, ui.Reset)
+			// Locked for as long as this process, or the tool replacing it,
+			// runs there, so cc worktrees prune leaves it alone
+			repo := dir
+			if err := git.LockWorktree(context.Background(), repo, wt.Path, git.LockReason(os.Getpid())); err != nil {
+				fmt.Fprintf(t, "  %s%s%s\n\n", ui.BrYell, err, ui.Reset)
+			} else {
+				unlock = func() { git.UnlockWorktree(context.Background(), repo, wt.Path) }
+			}
+			dir = wt.Path
+		}
+	}
+	if s.CdFile != "" {
+		if err := os.WriteFile(s.CdFile, []byte(dir), 0600); err != nil {
+			fmt.Fprintf(t, "  %sfailed to pass the directory to the shell: %s%s\n\n", ui.BrYell, err, ui.Reset)
+		}
+	}
+	return launch{command: command, dir: dir, env: Env(res.Profile), preLaunch: o.PreLaunch, unlock: unlock}
+}
+
+// newWorktree gives the repository in dir a new worktree under parent
+func newWorktree(dir, parent string) (git.Worktree, error) {
+	if !git.IsRepo(dir) {
+		return git.Worktree{}, fmt.Errorf("%s is not a git repository, so it has no worktrees", dir)
+	}
+	return git.NewWorktree(context.Background(), dir, parent)
+}
+
+// saveMark records project's pinned and hidden state in the config file.
+// The file is re-read so pins made in other windows are kept.
+func saveMark(project string, favorite, hidden bool) error {
+	cfg, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg.SetFavorite(project, favorite)
+	cfg.SetHidden(project, hidden)
+	return config.Save(cfg, "")
+}
+
+// title fills the placeholders in pattern from res
+func title(pattern string, res Result) string {
+	profile := ""
+	if res.Profile != nil {
+		profile = res.Profile.Name
+	}
+	return strings.NewReplacer(ProjectPlaceholder, res.Project, ProfilePlaceholder, profile).Replace(pattern)
+}
+
+// Env returns the environment that selects profile's account
+func Env(profile *config.Profile) map[string]string {
+	if profile == nil {
+		return nil
+	}
+	env := map[string]string{"CLAUDE_CONFIG_DIR": config.ExpandPath(profile.ConfigDir)}
+	if profile.APIKey != "" {
+		env["ANTHROPIC_API_KEY"] = profile.APIKey
+	}
+	return env
+}
+
+// ListProjects returns the directories in root, skipping hidden ones, sorted
+// by name ignoring case
+func ListProjects(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		isDir := e.IsDir()
+		if e.Type()&os.ModeSymlink != 0 {
+			info, err := os.Stat(filepath.Join(root, e.Name()))
+			isDir = err == nil && info.IsDir()
+		}
+		if isDir {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+	return names, nil
+}
+
+// MaxFrecent is how many projects the Recent section lists
+const MaxFrecent = 5
+
+// firstStatuses takes the statuses that arrive within budget, leaving the
+// rest on statuses
+func firstStatuses(statuses <-chan git.ProjectStatus, budget time.Duration) map[string]git.Status {
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+	status := map[string]git.Status{}
+	for {
+		select {
+		case ps, ok := <-statuses:
+			if !ok {
+				return status
+			}
+			status[ps.Project] = ps.Status
+		case <-timer.C:
+			return status
+		}
+	}
+}
+
+// recentProjects returns the projects in root that h ranks most frecent, and
+// when each project was last used. Projects never picked fall back to when
+// their directory was last modified.
+func recentProjects(h *history.History, root string, projects []string, now time.Time) ([]string, map[string]time.Time) {
+	picked := h.In(root)
+	recent := make(map[string]time.Time, len(projects))
+	var frecent []string
+	for _, p := range projects {
+		if e, ok := picked[p]; ok {
+			recent[p] = e.Last
+			frecent = append(frecent, p)
+		} else if info, err := os.Stat(filepath.Join(root, p)); err == nil {
+			recent[p] = info.ModTime()
+		}
+	}
+
+	sort.SliceStable(frecent, func(i, j int) bool {
+		return picked[frecent[i]].Frecency(now) > picked[frecent[j]].Frecency(now)
+	})
+	if len(frecent) > MaxFrecent {
+		frecent = frecent[:MaxFrecent]
+	}
+	return frecent, recent
+}
@@ -0,0 +1,80 @@
+package picker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/history"
+)
+
+func TestListProjects(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"web", "Api", ".git", "zeta"} {
+		if err := os.Mkdir(filepath.Join(root, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "web"), filepath.Join(root, "site")); err != nil {
+		t.Skip("symlinks unavailable:", err)
+	}
+
+	got, err := ListProjects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Api", "site", "web", "zeta"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRecentProjects(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	h := &history.History{Entries: []history.Entry{
+		{Path: filepath.Join(root, "api"), Count: 1, Last: now.Add(-time.Hour)},
+		{Path: filepath.Join(root, "web"), Count: 9, Last: now.Add(-2 * time.Hour)},
+		{Path: filepath.Join(root, "gone"), Count: 50, Last: now},
+		{Path: filepath.Join(t.TempDir(), "api"), Count: 99, Last: now},
+	}}
+
+	frecent, recent := recentProjects(h, root, []string{"api", "cli", "web"}, now)
+	if want := []string{"web", "api"}; !reflect.DeepEqual(frecent, want) {
+		t.Errorf("got frecent %v, want %v", frecent, want)
+	}
+	if !recent["api"].Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected api's last pick as its recency, got %v", recent["api"])
+	}
+	if _, ok := recent["cli"]; ok {
+		t.Errorf("expected no recency for a missing, never-picked directory")
+	}
+}
+
+func TestTitle(t *testing.T) {
+	res := Result{Project: "api", Profile: &config.Profile{Name: "work"}}
+	if got := title(ProjectPlaceholder+" · cc · "+ProfilePlaceholder+" #ab.1.1", res); got != "api · cc · work #ab.1.1" {
+		t.Errorf("unexpected title %q", got)
+	}
+	if got := title("cc "+ProfilePlaceholder, Result{Project: "api"}); got != "cc " {
+		t.Errorf("unexpected title without a profile %q", got)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	if Env(nil) != nil {
+		t.Errorf("expected no env without a profile")
+	}
+	env := Env(&config.Profile{ConfigDir: "/cfg/work", APIKey: "sk-1"})
+	if env["CLAUDE_CONFIG_DIR"] != "/cfg/work" || env["ANTHROPIC_API_KEY"] != "sk-1" {
+		t.Errorf("unexpected env %v", env)
+	}
+	if _, ok := Env(&config.Profile{ConfigDir: "/cfg"})["ANTHROPIC_API_KEY"]; ok {
+		t.Errorf("expected no API key when the profile has none")
+	}
+}
@@ -0,0 +1,144 @@
+package picker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+// predicates are the filter prefixes, typed as ":dirty" or any unique
+// abbreviation such as ":d"
+var predicates = map[string]func(git.Status) bool{
+	"dirty":  func(s git.Status) bool { return s.Dirty },
+	"clean":  func(s git.Status) bool { return s.Repo && !s.Dirty },
+	"ahead":  func(s git.Status) bool { return s.Ahead > 0 },
+	"behind": func(s git.Status) bool { return s.Behind > 0 },
+	"git":    func(s git.Status) bool { return s.Repo },
+}
+
+// splitFilter separates the ":prefix" words of filter from the fuzzy
+// query. Prefixes that match no predicate, or more than one, are ignored.
+func splitFilter(filter string) (string, []func(git.Status) bool) {
+	var query []string
+	var preds []func(git.Status) bool
+	for _, word := range strings.Fields(filter) {
+		if !strings.HasPrefix(word, ":") {
+			query = append(query, word)
+			continue
+		}
+		var found []func(git.Status) bool
+		for name, p := range predicates {
+			if strings.HasPrefix(name, word[1:]) {
+				found = append(found, p)
+			}
+		}
+		if len(found) == 1 {
+			preds = append(preds, found[0])
+		}
+	}
+	return strings.Join(query, ""), preds
+}
+
+// endsInPrefix reports whether the last word of filter is a ":prefix", the
+// only place a space is accepted
+func endsInPrefix(filter string) bool {
+	i := strings.LastIndex(filter, " ")
+	return strings.HasPrefix(filter[i+1:], ":") && len(filter) > i+1
+}
+
+// setStatus records the status of a project read in the background
+func (m *Model) setStatus(ps git.ProjectStatus) {
+	m.opts.Status[ps.Project] = ps.Status
+	m.statusChanged()
+}
+
+// statusesDone notes that no more statuses are coming
+func (m *Model) statusesDone() {
+	m.statuses = nil
+	m.statusChanged()
+}
+
+// statusChanged re-applies what depends on statuses, the filter prefixes
+// and the activity sort, keeping the selection
+func (m *Model) statusChanged() {
+	if _, preds := splitFilter(m.filter); len(preds) == 0 && m.sort != sortActivity {
+		return
+	}
+	name := m.selected()
+	m.refilter()
+	m.selectName(name)
+}
+
+// sortMode orders the project list
+type sortMode int
+
+const (
+	sortName     sortMode = iota // by name, or by match quality while filtering
+	sortActivity                 // by last commit, newest first
+	sortRecent                   // by last pick, newest first
+)
+
+var sortNames = []string{"name", "activity", "recent"}
+
+// sortRows orders rows by mode, keeping the current order among equals
+func (m *Model) sortRows(rows []row) {
+	var key func(name string) time.Time
+	switch m.sort {
+	case sortActivity:
+		key = func(name string) time.Time { return m.opts.Status[name].LastCommit }
+	case sortRecent:
+		key = func(name string) time.Time { return m.opts.Recent[name] }
+	default:
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return key(rows[i].name).After(key(rows[j].name))
+	})
+}
+
+// gitInfo renders s as "main ✓ ↑2 ↓1 3d"
+func gitInfo(s git.Status, now time.Time) string {
+	if !s.Repo {
+		return ""
+	}
+	branch := s.Branch
+	if branch == "" {
+		branch = "detached"
+	}
+	parts := []string{ui.BrCyan + branch + ui.Reset}
+	if s.Dirty {
+		parts = append(parts, ui.BrYell+"●"+ui.Reset)
+	} else {
+		parts = append(parts, ui.BrGreen+ui.Check+ui.Reset)
+	}
+	if s.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%s↑%d%s", ui.BrGreen, s.Ahead, ui.Reset))
+	}
+	if s.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%s↓%d%s", ui.BrRed, s.Behind, ui.Reset))
+	}
+	if !s.LastCommit.IsZero() {
+		parts = append(parts, ui.DkGray+age(now.Sub(s.LastCommit))+ui.Reset)
+	}
+	return strings.Join(parts, " ")
+}
+
+// age formats d compactly, e.g. "5m", "3h", "2d", "6w" or "1y"
+func age(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < day:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 14*day:
+		return fmt.Sprintf("%dd", int(d/day))
+	case d < 365*day:
+		return fmt.Sprintf("%dw", int(d/(7*day)))
+	}
+	return fmt.Sprintf("%dy", int(d/(365*day)))
+}
@@ -0,0 +1,148 @@
+package picker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcmister/cc/internal/git"
+	"github.com/bcmister/cc/internal/project"
+	"github.com/bcmister/cc/internal/ui"
+)
+
+func TestSplitFilter(t *testing.T) {
+	query, preds := splitFilter(":dirty api :b")
+	if query != "api" || len(preds) != 2 {
+		t.Fatalf("got query %q with %d predicates", query, len(preds))
+	}
+	if !preds[0](git.Status{Dirty: true}) || !preds[1](git.Status{Behind: 1}) {
+		t.Errorf("expected :dirty then :behind")
+	}
+
+	// ":" alone is ambiguous and ":zzz" matches nothing
+	if _, preds := splitFilter(": :zzz"); len(preds) != 0 {
+		t.Errorf("expected unknown prefixes to be ignored, got %d", len(preds))
+	}
+}
+
+func TestAge(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		5 * time.Minute:      "5m",
+		3 * time.Hour:        "3h",
+		50 * time.Hour:       "2d",
+		30 * 24 * time.Hour:  "4w",
+		800 * 24 * time.Hour: "2y",
+		364 * 24 * time.Hour: "52w",
+		14 * 24 * time.Hour:  "2w",
+	} {
+		if got := age(d); got != want {
+			t.Errorf("age(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestGitInfo(t *testing.T) {
+	now := time.Now()
+	got := gitInfo(git.Status{Repo: true, Branch: "main", Dirty: true, Ahead: 2, LastCommit: now.Add(-3 * time.Hour)}, now)
+	for _, want := range []string{"main", "●", "↑2", "3h"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+	if strings.Contains(got, "↓") {
+		t.Errorf("expected no behind count in %q", got)
+	}
+	if gitInfo(git.Status{}, now) != "" {
+		t.Errorf("expected nothing for a directory that is not a repository")
+	}
+}
+
+func TestModelStatusFiltersAndSorts(t *testing.T) {
+	now := time.Now()
+	status := map[string]git.Status{
+		"api":    {Repo: true, Branch: "main", Dirty: true, LastCommit: now.Add(-48 * time.Hour)},
+		"api-v2": {Repo: true, Branch: "next", LastCommit: now.Add(-time.Hour)},
+		"web":    {Repo: true, Branch: "main", Dirty: true, Behind: 3, LastCommit: now.Add(-5 * time.Minute)},
+	}
+	m := New(Options{Projects: projects, Status: status})
+
+	press(m, runes(":dirty")...)
+	if got := strings.Join(names(m), " "); got != "api web" {
+		t.Errorf("expected only dirty projects, got %q", got)
+	}
+	press(m, Key{Type: KeyRune, Rune: ' '}, Key{Type: KeyRune, Rune: 'w'})
+	if got := strings.Join(names(m), " "); got != "web" || m.filter != ":dirty w" {
+		t.Errorf("expected dirty projects matching w, got %q for %q", got, m.filter)
+	}
+	press(m, Key{Type: KeyRune, Rune: ' '})
+	if m.filter != ":dirty w" {
+		t.Errorf("expected a space after the query to be ignored, got %q", m.filter)
+	}
+
+	m = New(Options{Projects: projects, Status: status})
+	press(m, Key{Type: KeyTab})
+	if got := strings.Join(names(m), " "); got != "web api-v2 api api-old worker" {
+		t.Errorf("expected projects by last commit, got %q", got)
+	}
+	view := m.View(80, 24)
+	if !strings.Contains(view, "by activity") || !strings.Contains(view, ui.BrRed+"↓3") {
+		t.Errorf("expected the sort mode and git state in the view, got:\n%s", view)
+	}
+	press(m, Key{Type: KeyTab}, Key{Type: KeyTab})
+	if m.sort != sortName {
+		t.Errorf("expected tab to cycle back to sorting by name")
+	}
+}
+
+func TestModelStatusArrivesLate(t *testing.T) {
+	statuses := make(chan git.ProjectStatus)
+	m := New(Options{
+		Projects: projects,
+		Status:   map[string]git.Status{"api": {Repo: true, Dirty: true}},
+		Statuses: statuses,
+	})
+
+	// Projects still being read are kept and marked unknown
+	press(m, runes(":dirty")...)
+	if got := strings.Join(names(m), " "); got != "api api-old api-v2 web worker" {
+		t.Errorf("expected unknown projects to stay listed, got %q", got)
+	}
+	if view := m.View(80, 24); !strings.Contains(view, "…") {
+		t.Errorf("expected unknown statuses to be marked, got:\n%s", view)
+	}
+
+	press(m, Key{Type: KeyDown}, Key{Type: KeyDown}, Key{Type: KeyDown})
+	m.setStatus(git.ProjectStatus{Project: "api-old", Status: git.Status{Repo: true}})
+	m.setStatus(git.ProjectStatus{Project: "web", Status: git.Status{Repo: true, Dirty: true}})
+	if got := strings.Join(names(m), " "); got != "api api-v2 web worker" || m.selected() != "web" {
+		t.Errorf("expected clean api-old to go with web still selected, got %q on %q", got, m.selected())
+	}
+
+	m.statusesDone()
+	if got := strings.Join(names(m), " "); got != "api web" {
+		t.Errorf("expected only dirty projects once every status is in, got %q", got)
+	}
+}
+
+func TestModelViewShowsTypeBadges(t *testing.T) {
+	types := map[string]project.Info{"web": {Type: project.Node, Manager: "pnpm"}}
+	m := New(Options{Projects: []string{"api", "web"}, Types: types})
+	view := m.View(80, 24)
+	if !strings.Contains(view, "web"+ui.Reset+"    "+ui.DkGray+"[node:pnpm]") {
+		t.Errorf("expected web's badge beside it, got:\n%q", view)
+	}
+}
+
+func TestPreLaunch(t *testing.T) {
+	dir := t.TempDir()
+	var out strings.Builder
+	preLaunch(&out, []string{"exit 3", "echo ok > marker.txt"}, dir, nil)
+	if !strings.Contains(out.String(), ui.Cross+" exit 3") {
+		t.Errorf("expected the failing command to be reported, got %q", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "marker.txt")); err != nil {
+		t.Errorf("expected later commands to run in the project after a failure: %v", err)
+	}
+}
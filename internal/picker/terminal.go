@@ -0,0 +1,54 @@
+package picker
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// Terminal is the picker's view of a terminal. Stdio is the real one; tests
+// use a virtual terminal with scripted input.
+type Terminal interface {
+	io.ReadWriter
+	// Size returns the visible columns and rows
+	Size() (width, height int)
+	// Raw switches to unbuffered, unechoed input and returns a function that
+	// restores the previous mode
+	Raw() (restore func() error, err error)
+}
+
+// waiter is a Terminal that can wait for input without reading it, so the
+// picker can take in work finished in the background between keys
+type waiter interface {
+	// Wait reports whether input arrived within d
+	Wait(d time.Duration) (bool, error)
+}
+
+// Stdio returns the terminal attached to stdin and stdout
+func Stdio() Terminal {
+	return &stdio{in: os.Stdin, out: os.Stdout}
+}
+
+type stdio struct {
+	in  *os.File
+	out *os.File
+}
+
+func (s *stdio) Read(p []byte) (int, error)  { return s.in.Read(p) }
+func (s *stdio) Write(p []byte) (int, error) { return s.out.Write(p) }
+
+func (s *stdio) Size() (int, int) {
+	w, h, err := termSize(s.out.Fd())
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+func (s *stdio) Raw() (func() error, error) {
+	return makeRaw(s.in.Fd(), s.out.Fd())
+}
+
+func (s *stdio) Wait(d time.Duration) (bool, error) {
+	return inputReady(s.in.Fd(), d)
+}
@@ -0,0 +1,22 @@
+//go:build !linux && !darwin && !windows
+
+package picker
+
+import (
+	"fmt"
+	"time"
+)
+
+var errNoTerminal = fmt.Errorf("the picker is not supported on this platform")
+
+func makeRaw(in, out uintptr) (func() error, error) {
+	return nil, errNoTerminal
+}
+
+func termSize(fd uintptr) (int, int, error) {
+	return 0, 0, errNoTerminal
+}
+
+func inputReady(fd uintptr, d time.Duration) (bool, error) {
+	return false, errNoTerminal
+}
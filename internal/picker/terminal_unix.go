@@ -0,0 +1,70 @@
+//go:build linux || darwin
+
+package picker
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+func ioctl(fd, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeRaw puts the tty in raw input mode, leaving output processing on so
+// "\n" still returns the carriage
+func makeRaw(in, out uintptr) (func() error, error) {
+	var old syscall.Termios
+	if err := ioctl(in, ioctlGetTermios, unsafe.Pointer(&old)); err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(in, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return ioctl(in, ioctlSetTermios, unsafe.Pointer(&old))
+	}, nil
+}
+
+func termSize(fd uintptr) (int, int, error) {
+	var ws struct{ Row, Col, X, Y uint16 }
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return 0, 0, err
+	}
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// inputReady waits up to d for fd to have input to read
+func inputReady(fd uintptr, d time.Duration) (bool, error) {
+	var set syscall.FdSet
+	bits := uint(unsafe.Sizeof(set.Bits[0]) * 8)
+	i, bit := fd/uintptr(bits), uint(fd)%bits
+	if i >= uintptr(len(set.Bits)) {
+		// Out of select's reach; reading just blocks
+		return true, nil
+	}
+	set.Bits[i] |= 1 << bit
+
+	tv := syscall.NsecToTimeval(d.Nanoseconds())
+	if err := selectRead(int(fd)+1, &set, &tv); err != nil {
+		if err == syscall.EINTR {
+			return false, nil
+		}
+		return false, err
+	}
+	return set.Bits[i]&(1<<bit) != 0, nil
+}
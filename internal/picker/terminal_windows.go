@@ -0,0 +1,138 @@
+package picker
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode             = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+	procSetConsoleCP               = kernel32.NewProc("SetConsoleCP")
+	procGetConsoleCP               = kernel32.NewProc("GetConsoleCP")
+	procPeekConsoleInputW          = kernel32.NewProc("PeekConsoleInputW")
+	procReadConsoleInputW          = kernel32.NewProc("ReadConsoleInputW")
+)
+
+const (
+	enableProcessedInput            = 0x0001
+	enableLineInput                 = 0x0002
+	enableEchoInput                 = 0x0004
+	enableVirtualTerminalInput      = 0x0200
+	enableVirtualTerminalProcessing = 0x0004
+	cpUTF8                          = 65001
+	keyEvent                        = 0x0001
+)
+
+func getConsoleMode(h uintptr) (uint32, error) {
+	var mode uint32
+	ret, _, err := procGetConsoleMode.Call(h, uintptr(unsafe.Pointer(&mode)))
+	if ret == 0 {
+		return 0, err
+	}
+	return mode, nil
+}
+
+func setConsoleMode(h uintptr, mode uint32) error {
+	ret, _, err := procSetConsoleMode.Call(h, uintptr(mode))
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
+
+// makeRaw turns off line input and echo and switches both handles to VT
+// sequences, so keys arrive as the same escape codes a Unix tty sends
+func makeRaw(in, out uintptr) (func() error, error) {
+	oldIn, err := getConsoleMode(in)
+	if err != nil {
+		return nil, err
+	}
+	oldOut, err := getConsoleMode(out)
+	if err != nil {
+		return nil, err
+	}
+	oldCP, _, _ := procGetConsoleCP.Call()
+
+	rawIn := oldIn&^(enableProcessedInput|enableLineInput|enableEchoInput) | enableVirtualTerminalInput
+	if err := setConsoleMode(in, rawIn); err != nil {
+		return nil, err
+	}
+	if err := setConsoleMode(out, oldOut|enableVirtualTerminalProcessing); err != nil {
+		setConsoleMode(in, oldIn)
+		return nil, err
+	}
+	procSetConsoleCP.Call(cpUTF8)
+
+	return func() error {
+		procSetConsoleCP.Call(oldCP)
+		setConsoleMode(out, oldOut)
+		return setConsoleMode(in, oldIn)
+	}, nil
+}
+
+type coord struct{ X, Y int16 }
+
+type smallRect struct{ Left, Top, Right, Bottom int16 }
+
+type consoleScreenBufferInfo struct {
+	Size              coord
+	CursorPosition    coord
+	Attributes        uint16
+	Window            smallRect
+	MaximumWindowSize coord
+}
+
+func termSize(fd uintptr) (int, int, error) {
+	var info consoleScreenBufferInfo
+	ret, _, err := procGetConsoleScreenBufferInfo.Call(fd, uintptr(unsafe.Pointer(&info)))
+	if ret == 0 {
+		return 0, 0, err
+	}
+	return int(info.Window.Right-info.Window.Left) + 1, int(info.Window.Bottom-info.Window.Top) + 1, nil
+}
+
+// inputRecord is an INPUT_RECORD holding a KEY_EVENT_RECORD
+type inputRecord struct {
+	EventType   uint16
+	_           uint16
+	KeyDown     int32
+	RepeatCount uint16
+	VirtualKey  uint16
+	ScanCode    uint16
+	Char        uint16
+	ControlKeys uint32
+}
+
+// inputReady waits up to d for the console h to have a key to read. Events
+// a read skips, such as focus changes and key releases, are dropped so they
+// do not report input that never comes.
+func inputReady(h uintptr, d time.Duration) (bool, error) {
+	ev, err := syscall.WaitForSingleObject(syscall.Handle(h), uint32(d.Milliseconds()))
+	if err != nil {
+		return false, err
+	}
+	if ev != syscall.WAIT_OBJECT_0 {
+		return false, nil
+	}
+
+	for {
+		var rec inputRecord
+		var n uint32
+		if ret, _, err := procPeekConsoleInputW.Call(h, uintptr(unsafe.Pointer(&rec)), 1, uintptr(unsafe.Pointer(&n))); ret == 0 {
+			return false, err
+		}
+		if n == 0 {
+			return false, nil
+		}
+		if rec.EventType == keyEvent && rec.KeyDown != 0 && rec.Char != 0 {
+			return true, nil
+		}
+		if ret, _, err := procReadConsoleInputW.Call(h, uintptr(unsafe.Pointer(&rec)), 1, uintptr(unsafe.Pointer(&n))); ret == 0 {
+			return false, err
+		}
+	}
+}
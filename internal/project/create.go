@@ -0,0 +1,125 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/git"
+)
+
+// TemplatesDir returns the directory holding project templates, one
+// subdirectory each, next to the config file
+func TemplatesDir() string {
+	return filepath.Join(filepath.Dir(config.DefaultConfigPath()), "templates")
+}
+
+// ListTemplates returns the template names in dir, sorted. A missing
+// directory has no templates.
+func ListTemplates(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidName reports whether name can be created as a project directly
+// inside the projects root
+func ValidName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\:*?"<>|`)
+}
+
+// CreateOptions say how a new project is set up
+type CreateOptions struct {
+	Template string // template directory to copy; empty creates an empty project
+	GitInit  bool
+}
+
+// Create makes the project name in root, copies the template into it and
+// initializes a repository, returning the new project's directory
+func Create(ctx context.Context, root, name string, opts CreateOptions) (string, error) {
+	if !ValidName(name) {
+		return "", fmt.Errorf("invalid project name %q", name)
+	}
+	dir := filepath.Join(root, name)
+	if err := os.Mkdir(dir, 0755); err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("project %s already exists", name)
+		}
+		return "", fmt.Errorf("failed to create project: %w", err)
+	}
+
+	if opts.Template != "" {
+		if err := copyTree(opts.Template, dir); err != nil {
+			return dir, fmt.Errorf("failed to copy template: %w", err)
+		}
+	}
+	if opts.GitInit {
+		if err := git.Init(ctx, dir); err != nil {
+			return dir, err
+		}
+	}
+	return dir, nil
+}
+
+// copyTree copies the files and directories under src into dst, leaving
+// out the template's own .git directory
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"api":      true,
+		"api v2":   true,
+		"":         false,
+		".hidden":  false,
+		"a/b":      false,
+		`a\b`:      false,
+		"what?":    false,
+		"C:thing":  false,
+		"api-next": true,
+	} {
+		if got := ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestCreateFromTemplate(t *testing.T) {
+	tmpl := t.TempDir()
+	for name, body := range map[string]string{
+		"README.md":      "# new",
+		"cmd/main.go":    "package main",
+		".git/HEAD":      "ref: refs/heads/main",
+		".github/ci.yml": "on: push",
+	} {
+		path := filepath.Join(tmpl, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := t.TempDir()
+	dir, err := Create(context.Background(), root, "svc", CreateOptions{Template: tmpl})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if want := []string{".github/ci.yml", "README.md", "cmd/main.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got files %v, want %v", got, want)
+	}
+
+	if _, err := Create(context.Background(), root, "svc", CreateOptions{}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected creating svc twice to fail, got %v", err)
+	}
+	if _, err := Create(context.Background(), root, "../escape", CreateOptions{}); err == nil {
+		t.Errorf("expected a name with a separator to be rejected")
+	}
+}
+
+func TestCreateGitInit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir, err := Create(context.Background(), t.TempDir(), "repo", CreateOptions{GitInit: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		t.Errorf("expected a repository: %v", err)
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"node", "go", ".cache"} {
+		os.Mkdir(filepath.Join(dir, d), 0755)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644)
+
+	if got := ListTemplates(dir); !reflect.DeepEqual(got, []string{"go", "node"}) {
+		t.Errorf("unexpected templates %v", got)
+	}
+	if got := ListTemplates(filepath.Join(dir, "missing")); got != nil {
+		t.Errorf("expected no templates in a missing directory, got %v", got)
+	}
+}
@@ -0,0 +1,128 @@
+// Package project recognizes what kind of project a directory holds from
+// the marker files at its top.
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Type is a kind of project
+type Type string
+
+const (
+	Unknown  Type = ""
+	Monorepo Type = "monorepo"
+	Go       Type = "go"
+	Rust     Type = "rust"
+	Node     Type = "node"
+	Python   Type = "python"
+)
+
+// Types lists the detectable types, most specific first
+var Types = []Type{Monorepo, Go, Rust, Node, Python}
+
+// Info is what was detected in a directory
+type Info struct {
+	Type    Type
+	Manager string // Node package manager: "npm", "pnpm", "yarn" or "bun"
+}
+
+// Badge is the short label shown beside the project, e.g. "go" or "node:pnpm"
+func (i Info) Badge() string {
+	if i.Manager != "" {
+		return string(i.Type) + ":" + i.Manager
+	}
+	return string(i.Type)
+}
+
+// monorepoMarkers are files found only at the root of a workspace
+var monorepoMarkers = []string{"go.work", "pnpm-workspace.yaml", "turbo.json", "nx.json", "lerna.json", "rush.json"}
+
+// pythonMarkers are any of the files a Python project is set up with
+var pythonMarkers = []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt", "Pipfile"}
+
+// lockfiles name the package manager that wrote them, in order of precedence
+var lockfiles = []struct{ file, manager string }{
+	{"pnpm-lock.yaml", "pnpm"},
+	{"yarn.lock", "yarn"},
+	{"bun.lockb", "bun"},
+	{"bun.lock", "bun"},
+	{"package-lock.json", "npm"},
+}
+
+// Detect returns what dir holds. A directory matching several types gets
+// the first of Types; Manager is set whenever there is a package.json.
+func Detect(dir string) Info {
+	has := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	var info Info
+	if has("package.json") {
+		info.Manager = "npm"
+		for _, l := range lockfiles {
+			if has(l.file) {
+				info.Manager = l.manager
+				break
+			}
+		}
+	}
+
+	switch {
+	case anyOf(has, monorepoMarkers) || hasWorkspaces(dir):
+		info.Type = Monorepo
+	case has("go.mod"):
+		info.Type = Go
+	case has("Cargo.toml"):
+		info.Type = Rust
+	case has("package.json"):
+		info.Type = Node
+	case anyOf(has, pythonMarkers):
+		info.Type = Python
+	}
+	if info.Type != Node && info.Type != Monorepo {
+		info.Manager = ""
+	}
+	return info
+}
+
+// DetectAll detects each project in root
+func DetectAll(root string, projects []string) map[string]Info {
+	infos := make(map[string]Info, len(projects))
+	for _, p := range projects {
+		if info := Detect(filepath.Join(root, p)); info.Type != Unknown {
+			infos[p] = info
+		}
+	}
+	return infos
+}
+
+func anyOf(has func(string) bool, names []string) bool {
+	for _, n := range names {
+		if has(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWorkspaces reports whether dir's package.json or Cargo.toml declares
+// workspaces
+func hasWorkspaces(dir string) bool {
+	if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && len(pkg.Workspaces) > 0 && string(pkg.Workspaces) != "null" {
+			return true
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "Cargo.toml")); err == nil {
+		return bytes.Contains(data, []byte("[workspace]"))
+	}
+	return false
+}
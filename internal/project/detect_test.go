@@ -0,0 +1,54 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tree creates files in a new directory and returns it
+func tree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDetect(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		files map[string]string
+		want  Info
+	}{
+		{"empty", nil, Info{}},
+		{"go", map[string]string{"go.mod": "module x"}, Info{Type: Go}},
+		{"rust", map[string]string{"Cargo.toml": "[package]"}, Info{Type: Rust}},
+		{"npm", map[string]string{"package.json": "{}"}, Info{Type: Node, Manager: "npm"}},
+		{"pnpm", map[string]string{"package.json": "{}", "pnpm-lock.yaml": ""}, Info{Type: Node, Manager: "pnpm"}},
+		{"yarn", map[string]string{"package.json": "{}", "yarn.lock": "", "package-lock.json": ""}, Info{Type: Node, Manager: "yarn"}},
+		{"python", map[string]string{"requirements.txt": ""}, Info{Type: Python}},
+		// A Go service with a frontend is still a Go project
+		{"go with node", map[string]string{"go.mod": "", "package.json": "{}"}, Info{Type: Go}},
+		{"pnpm workspace", map[string]string{"package.json": "{}", "pnpm-workspace.yaml": "", "pnpm-lock.yaml": ""}, Info{Type: Monorepo, Manager: "pnpm"}},
+		{"npm workspaces", map[string]string{"package.json": `{"workspaces": ["apps/*"]}`}, Info{Type: Monorepo, Manager: "npm"}},
+		{"cargo workspace", map[string]string{"Cargo.toml": "[workspace]\nmembers = []"}, Info{Type: Monorepo}},
+		{"go workspace", map[string]string{"go.work": "", "go.mod": ""}, Info{Type: Monorepo}},
+	} {
+		if got := Detect(tree(t, tc.files)); got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestBadge(t *testing.T) {
+	if got := (Info{Type: Node, Manager: "pnpm"}).Badge(); got != "node:pnpm" {
+		t.Errorf("unexpected badge %q", got)
+	}
+	if got := (Info{Type: Go}).Badge(); got != "go" {
+		t.Errorf("unexpected badge %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Package registry remembers the windows launched by the last run so that
+// later commands can find them again.
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bcmister/cc/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Window is one terminal launched by a run
+type Window struct {
+	Title   string `yaml:"title"`
+	Monitor int    `yaml:"monitor"` // zero-based monitor index
+	Index   int    `yaml:"index"`   // zero-based window index on the monitor
+}
+
+// Run records the windows launched by one cc all
+type Run struct {
+	ID      string    `yaml:"id"`
+	Started time.Time `yaml:"started"`
+	Windows []Window  `yaml:"windows"`
+}
+
+// DefaultPath returns the registry file next to the config file
+func DefaultPath() string {
+	return filepath.Join(filepath.Dir(config.DefaultConfigPath()), "run.yaml")
+}
+
+// Load reads the last recorded run
+func Load(path string) (*Run, error) {
+	if path == "" {
+		path = DefaultPath()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &Run{}
+	if err := yaml.Unmarshal(data, run); err != nil {
+		return nil, fmt.Errorf("failed to parse run registry: %w", err)
+	}
+	return run, nil
+}
+
+// Save records run, replacing any earlier one
+func Save(run *Run, path string) error {
+	if path == "" {
+		path = DefaultPath()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create registry directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal run registry: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run registry: %w", err)
+	}
+	return nil
+}
@@ -2,9 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 )
 
+// Out is where the helpers print. Commands whose stdout carries data, such
+// as a plan, point it at stderr.
+var Out io.Writer = os.Stdout
+
 // ANSI color codes
 const (
 	Reset   = "\033[0m"
@@ -47,39 +53,39 @@ var logoLines = []struct {
 
 // Logo prints the cc ASCII art with an optional right-side subtitle.
 func Logo(subtitle string) {
-	fmt.Println()
+	fmt.Fprintln(Out)
 	for i, l := range logoLines {
-		fmt.Printf(" %s%s%s", l.color, l.text, Reset)
+		fmt.Fprintf(Out, " %s%s%s", l.color, l.text, Reset)
 		if i == 1 && subtitle != "" {
-			fmt.Printf("   %s%s%s%s", Bold, BrWhite, subtitle, Reset)
+			fmt.Fprintf(Out, "   %s%s%s%s", Bold, BrWhite, subtitle, Reset)
 		}
-		fmt.Println()
+		fmt.Fprintln(Out)
 	}
 }
 
 // Sep prints a dim horizontal rule.
 func Sep() {
-	fmt.Printf("\n %s%s%s\n", DkGray, strings.Repeat("─", 40), Reset)
+	fmt.Fprintf(Out, "\n %s%s%s\n", DkGray, strings.Repeat("─", 40), Reset)
 }
 
 // Head prints a section header with a diamond bullet.
 func Head(text string) {
-	fmt.Printf("\n %s%s%s %s%s%s\n", BrCyan, Diamond, Reset, BrWhite, text, Reset)
+	fmt.Fprintf(Out, "\n %s%s%s %s%s%s\n", BrCyan, Diamond, Reset, BrWhite, text, Reset)
 }
 
 // Ok prints a success line.
 func Ok(text string) {
-	fmt.Printf(" %s%s%s %s%s%s\n", BrGreen, Diamond, Reset, BrWhite, text, Reset)
+	fmt.Fprintf(Out, " %s%s%s %s%s%s\n", BrGreen, Diamond, Reset, BrWhite, text, Reset)
 }
 
 // Warn prints a warning line.
 func Warn(text string) {
-	fmt.Printf("   %s%s %s%s\n", BrYell, Bullet, text, Reset)
+	fmt.Fprintf(Out, "   %s%s %s%s\n", BrYell, Bullet, text, Reset)
 }
 
 // Err prints an error line.
 func Err(text string) {
-	fmt.Printf("   %s%s %s%s\n", BrRed, Cross, text, Reset)
+	fmt.Fprintf(Out, "   %s%s %s%s\n", BrRed, Cross, text, Reset)
 }
 
 // Item prints a list row with a pass/fail indicator.
@@ -88,25 +94,25 @@ func Item(label string, ok bool) {
 	if !ok {
 		mark = fmt.Sprintf("%s%s%s", BrRed, Cross, Reset)
 	}
-	fmt.Printf("   %s%s%s %-26s %s\n", DkGray, Bullet, Reset, label, mark)
+	fmt.Fprintf(Out, "   %s%s%s %-26s %s\n", DkGray, Bullet, Reset, label, mark)
 }
 
 // Prompt prints a styled prompt. The caller still reads stdin.
 func Prompt(label, defaultVal string) {
-	fmt.Printf("\n %s%s%s %s%s%s", BrCyan, Diamond, Reset, BrWhite, label, Reset)
+	fmt.Fprintf(Out, "\n %s%s%s %s%s%s", BrCyan, Diamond, Reset, BrWhite, label, Reset)
 	if defaultVal != "" {
-		fmt.Printf(" %s[%s]%s", DkGray, defaultVal, Reset)
+		fmt.Fprintf(Out, " %s[%s]%s", DkGray, defaultVal, Reset)
 	}
-	fmt.Printf("\n   %s%s%s ", BrCyan, Arrow, Reset)
+	fmt.Fprintf(Out, "\n   %s%s%s ", BrCyan, Arrow, Reset)
 }
 
 // Inline prints a single-line prompt (no newline before arrow).
 func Inline(label, defaultVal string) {
-	fmt.Printf(" %s%s%s %s%s%s", BrCyan, Diamond, Reset, BrWhite, label, Reset)
+	fmt.Fprintf(Out, " %s%s%s %s%s%s", BrCyan, Diamond, Reset, BrWhite, label, Reset)
 	if defaultVal != "" {
-		fmt.Printf(" %s[%s]%s", DkGray, defaultVal, Reset)
+		fmt.Fprintf(Out, " %s[%s]%s", DkGray, defaultVal, Reset)
 	}
-	fmt.Printf("  %s%s%s ", BrCyan, Arrow, Reset)
+	fmt.Fprintf(Out, "  %s%s%s ", BrCyan, Arrow, Reset)
 }
 
 // BoxStart prints the top border of a panel.
@@ -117,7 +123,7 @@ func BoxStart(title string, badge string) {
 		badgeStr = fmt.Sprintf(" %s%s%s%s ", BrYell, Bold, badge, Reset+DkGray)
 		pad = max(34-len(title)-len(badge)-2, 1)
 	}
-	fmt.Printf("   %s┌─ %s%s%s%s %s%s─┐%s\n",
+	fmt.Fprintf(Out, "   %s┌─ %s%s%s%s %s%s─┐%s\n",
 		DkGray, BrWhite, title, Reset, DkGray+badgeStr, DkGray, strings.Repeat("─", pad), Reset)
 }
 
@@ -125,19 +131,19 @@ func BoxStart(title string, badge string) {
 func BoxRow(text string) {
 	vis := visLen(text)
 	pad := max(35-vis, 0)
-	fmt.Printf("   %s│%s  %s%s%s│%s\n", DkGray, Reset, text, strings.Repeat(" ", pad), DkGray, Reset)
+	fmt.Fprintf(Out, "   %s│%s  %s%s%s│%s\n", DkGray, Reset, text, strings.Repeat(" ", pad), DkGray, Reset)
 }
 
 // BoxEnd prints the bottom border of a panel.
 func BoxEnd() {
-	fmt.Printf("   %s└%s┘%s\n", DkGray, strings.Repeat("─", 37), Reset)
+	fmt.Fprintf(Out, "   %s└%s┘%s\n", DkGray, strings.Repeat("─", 37), Reset)
 }
 
 // Fin prints a closing status line after a separator.
 func Fin(text string) {
 	Sep()
 	Ok(text)
-	fmt.Println()
+	fmt.Fprintln(Out)
 }
 
 // visLen returns the printed width of s, ignoring ANSI escapes.
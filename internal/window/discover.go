@@ -0,0 +1,135 @@
+package window
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultFindTimeout = 5 * time.Second
+	DefaultBackoff     = 25 * time.Millisecond
+	DefaultMaxBackoff  = 250 * time.Millisecond
+)
+
+// WindowInfo is a top-level window as seen by an Enumerator
+type WindowInfo struct {
+	Handle uintptr
+	Title  string
+}
+
+// Enumerator lists the top-level windows currently on screen
+type Enumerator interface {
+	Windows() ([]WindowInfo, error)
+}
+
+// ListWindows returns the top-level windows currently on screen
+func ListWindows() ([]WindowInfo, error) {
+	return win32Enumerator{}.Windows()
+}
+
+// Finder waits for windows to appear. A window matches a title when the
+// two are equal or end in the same run tag, so windows are still found after
+// the picker renames them. It polls the enumerator with exponential backoff
+// until every title is found, the timeout passes or the context is cancelled.
+type Finder struct {
+	Enum       Enumerator
+	Timeout    time.Duration // total wait; zero means DefaultFindTimeout
+	Backoff    time.Duration // first poll interval; zero means DefaultBackoff
+	MaxBackoff time.Duration // poll interval cap; zero means DefaultMaxBackoff
+}
+
+// FindAll returns the handle of each title it found. When some titles never
+// appear the windows that were found are still returned, together with an
+// error naming the missing ones.
+func (f *Finder) FindAll(ctx context.Context, titles []string) (map[string]uintptr, error) {
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultFindTimeout
+	}
+	delay := f.Backoff
+	if delay <= 0 {
+		delay = DefaultBackoff
+	}
+	maxDelay := f.MaxBackoff
+	if maxDelay <= 0 {
+		maxDelay = DefaultMaxBackoff
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	found := make(map[string]uintptr, len(titles))
+	var lastErr error
+	for {
+		windows, err := f.Enum.Windows()
+		if err != nil {
+			lastErr = err
+		}
+		for _, w := range windows {
+			for _, title := range titles {
+				if _, ok := found[title]; !ok && titleMatches(w.Title, title) {
+					found[title] = w.Handle
+				}
+			}
+		}
+		if len(found) == len(titles) {
+			return found, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return found, missingError(titles, found, lastErr)
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// Find waits for a single window by title
+func (f *Finder) Find(ctx context.Context, title string) (uintptr, error) {
+	found, err := f.FindAll(ctx, []string{title})
+	if err != nil {
+		return 0, err
+	}
+	return found[title], nil
+}
+
+func titleMatches(got, want string) bool {
+	if got == want {
+		return true
+	}
+	tag := TitleTag(want)
+	return tag != "" && TitleTag(got) == tag
+}
+
+func missingError(titles []string, found map[string]uintptr, cause error) error {
+	var missing []string
+	for _, title := range titles {
+		if _, ok := found[title]; !ok {
+			missing = append(missing, fmt.Sprintf("'%s'", title))
+		}
+	}
+	sort.Strings(missing)
+	if cause != nil {
+		return fmt.Errorf("window with title %s not found: %w", strings.Join(missing, ", "), cause)
+	}
+	return fmt.Errorf("window with title %s not found", strings.Join(missing, ", "))
+}
+
+// NewRunID returns a short random ID that tags the windows of one launch,
+// so concurrent runs never pick up each other's windows
+func NewRunID() string {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%06x", time.Now().UnixNano()&0xffffff)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,110 @@
+package window
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEnumerator reveals its windows one poll at a time
+type fakeEnumerator struct {
+	mu      sync.Mutex
+	windows []WindowInfo
+	polls   int
+}
+
+func (f *fakeEnumerator) Windows() ([]WindowInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.polls++
+	n := f.polls
+	if n > len(f.windows) {
+		n = len(f.windows)
+	}
+	return f.windows[:n], nil
+}
+
+func fastFinder(enum Enumerator, timeout time.Duration) *Finder {
+	return &Finder{Enum: enum, Timeout: timeout, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+func TestFinderWaitsForAllWindows(t *testing.T) {
+	enum := &fakeEnumerator{windows: []WindowInfo{
+		{Handle: 1, Title: "Windows PowerShell"},
+		{Handle: 2, Title: "cc-1-2 #a1.1.2"},
+		{Handle: 3, Title: "cc-1-1 #a1.1.1"},
+	}}
+
+	found, err := fastFinder(enum, time.Second).FindAll(context.Background(), []string{"cc-1-1 #a1.1.1", "cc-1-2 #a1.1.2"})
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if found["cc-1-1 #a1.1.1"] != 3 || found["cc-1-2 #a1.1.2"] != 2 {
+		t.Errorf("unexpected handles %v", found)
+	}
+	if enum.polls < 3 {
+		t.Errorf("expected to keep polling until the last window appeared, polled %d times", enum.polls)
+	}
+}
+
+func TestFinderMatchesTitleOrTag(t *testing.T) {
+	enum := &fakeEnumerator{windows: []WindowInfo{
+		{Handle: 1, Title: "cc-1-10 #a1.1.10"},
+		{Handle: 2, Title: "cc-1-1 #b2.1.1"}, // another run's window
+		{Handle: 3, Title: "x cc-1-1 #a1.1.1 x"},
+	}}
+
+	_, err := fastFinder(enum, 20*time.Millisecond).Find(context.Background(), "cc-1-1 #a1.1.1")
+	if err == nil {
+		t.Fatalf("expected no match for cc-1-1 #a1.1.1")
+	}
+	if !strings.Contains(err.Error(), "'cc-1-1 #a1.1.1'") {
+		t.Errorf("expected error to name the missing title, got %v", err)
+	}
+}
+
+func TestFinderFindsRenamedWindows(t *testing.T) {
+	enum := &fakeEnumerator{windows: []WindowInfo{
+		{Handle: 4, Title: "api-server · cc · work #a1.1.1"},
+	}}
+
+	hwnd, err := fastFinder(enum, 20*time.Millisecond).Find(context.Background(), "cc-1-1 #a1.1.1")
+	if err != nil || hwnd != 4 {
+		t.Errorf("expected the renamed window to match by tag, got %d, %v", hwnd, err)
+	}
+}
+
+func TestFinderReturnsPartialResults(t *testing.T) {
+	enum := &fakeEnumerator{windows: []WindowInfo{{Handle: 7, Title: "cc-1-1 #a1.1.1"}}}
+
+	found, err := fastFinder(enum, 20*time.Millisecond).FindAll(context.Background(), []string{"cc-1-1 #a1.1.1", "cc-1-2 #a1.1.2"})
+	if err == nil || !strings.Contains(err.Error(), "cc-1-2 #a1.1.2") {
+		t.Errorf("expected error naming cc-1-2, got %v", err)
+	}
+	if found["cc-1-1 #a1.1.1"] != 7 {
+		t.Errorf("expected the found window to be returned, got %v", found)
+	}
+}
+
+func TestFinderHonoursContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := fastFinder(&fakeEnumerator{}, time.Hour).Find(ctx, "cc-1-1 #a1.1.1")
+	if err == nil {
+		t.Fatalf("expected error from cancelled context")
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("cancelled find took %s", time.Since(start))
+	}
+}
+
+func TestNewRunID(t *testing.T) {
+	a, b := NewRunID(), NewRunID()
+	if len(a) != 6 || a == b {
+		t.Errorf("expected distinct 6-character IDs, got %q and %q", a, b)
+	}
+}
@@ -0,0 +1,130 @@
+package window
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bcmister/cc/internal/config"
+)
+
+// DefaultLauncher is the terminal used when the config does not name one
+const DefaultLauncher = "wt"
+
+// Spec describes a single terminal for a Launcher to open
+type Spec struct {
+	Title string
+	Dir   string
+	Argv  []string          // program to run inside the terminal
+	Env   map[string]string // extra environment for Argv
+	Position
+}
+
+// Launcher opens terminals in a particular terminal emulator
+type Launcher interface {
+	// Name returns the key the launcher is registered under
+	Name() string
+	// NewWindow opens a new top-level terminal window
+	NewWindow(spec Spec) error
+	// NewTab opens a tab in the terminal window we are running in
+	NewTab(spec Spec) error
+	// SplitPane splits the focused pane of the terminal we are running in
+	SplitPane(spec Spec) error
+}
+
+// Placer is implemented by launchers whose windows are moved into place
+// after they appear on screen, rather than opened at their final geometry.
+type Placer interface {
+	// Place waits for the windows opened for specs and moves each to its
+	// position, returning one error (or nil) per spec
+	Place(ctx context.Context, specs []Spec) []error
+	// PlaceCurrent moves the terminal we are running in to spec's position
+	PlaceCurrent(spec Spec) error
+}
+
+// Group is the set of terminals that share one monitor
+type Group struct {
+	Monitor int    // zero-based monitor index
+	Layout  string // layout name from the monitor config
+	Configs []LaunchConfig
+}
+
+// GroupLauncher is implemented by launchers that open a whole monitor's
+// worth of terminals at once, as panes of a single window.
+type GroupLauncher interface {
+	LaunchGroup(g Group) error
+}
+
+// Describer is implemented by launchers that can report the command line
+// NewWindow would run, so a launch can be planned without side effects
+type Describer interface {
+	DescribeWindow(spec Spec) []string
+}
+
+// GroupDescriber is the Describer for group launchers: it reports every
+// command LaunchGroup would run for groups, in order
+type GroupDescriber interface {
+	DescribeGroups(groups []Group) [][]string
+}
+
+// Attacher is implemented by launchers whose terminals live in a session
+// that the current terminal joins once everything has been launched.
+type Attacher interface {
+	Attach() error
+}
+
+var launchers = map[string]func(cfg *config.Config) Launcher{
+	"wt":       func(cfg *config.Config) Launcher { return &WindowsTerminal{Finder: finderFor(cfg)} },
+	"wt-panes": func(cfg *config.Config) Launcher { return &WindowsTerminalPanes{} },
+	"tmux": func(cfg *config.Config) Launcher {
+		return &Tmux{Session: cfg.Tmux.Session, JoinExisting: cfg.Tmux.Attach}
+	},
+}
+
+// NewLauncher returns the launcher named by cfg.Terminal, or the default when it is empty
+func NewLauncher(cfg *config.Config) (Launcher, error) {
+	name := cfg.Terminal
+	if name == "" {
+		name = DefaultLauncher
+	}
+	newFn, ok := launchers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown terminal %q (available: %s)", name, strings.Join(LauncherNames(), ", "))
+	}
+	return newFn(cfg), nil
+}
+
+// finderFor builds the window finder from the config's discovery settings
+func finderFor(cfg *config.Config) Finder {
+	return Finder{
+		Timeout:    cfg.Discovery.Timeout,
+		Backoff:    cfg.Discovery.Backoff,
+		MaxBackoff: cfg.Discovery.MaxBackoff,
+	}
+}
+
+// LauncherNames returns the registered launcher names in sorted order
+func LauncherNames() []string {
+	names := make([]string, 0, len(launchers))
+	for name := range launchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SpecFor converts a launch config into a spec that runs the project picker
+func SpecFor(cfg LaunchConfig) Spec {
+	return Spec{
+		Title: cfg.Title,
+		Dir:   cfg.WorkingDir,
+		Argv:  pickerArgv(cfg),
+		Position: Position{
+			X:      cfg.X,
+			Y:      cfg.Y,
+			Width:  cfg.Width,
+			Height: cfg.Height,
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package window
+
+import (
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+)
+
+func TestNewLauncher(t *testing.T) {
+	for _, name := range []string{"", "wt", "WT"} {
+		l, err := NewLauncher(&config.Config{Terminal: name})
+		if err != nil {
+			t.Fatalf("NewLauncher(%q) failed: %v", name, err)
+		}
+		if l.Name() != "wt" {
+			t.Errorf("NewLauncher(%q) = %s, want wt", name, l.Name())
+		}
+	}
+
+	if _, err := NewLauncher(&config.Config{Terminal: "nope"}); err == nil {
+		t.Errorf("expected error for unknown terminal")
+	}
+}
@@ -0,0 +1,136 @@
+package window
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/monitor"
+	"github.com/bcmister/cc/internal/picker"
+)
+
+// Opener returns the Open function for picker session s: it starts the
+// extra projects in new windows through the configured terminal and lays
+// them out, with the current window first, on the current monitor
+func Opener(s picker.Session) func(res picker.Result, projects []string) error {
+	return func(res picker.Result, projects []string) error {
+		// Without a config the default terminal and layout are used
+		cfg, err := config.Load("")
+		if err != nil {
+			cfg = &config.Config{}
+		}
+		l, err := NewLauncher(cfg)
+		if err != nil {
+			return err
+		}
+		// The picker may be running in the session cc made, which would
+		// otherwise refuse new windows
+		if tm, ok := l.(*Tmux); ok {
+			if err := tm.JoinCurrent(); err != nil {
+				return err
+			}
+		}
+
+		base := LaunchConfig{WorkingDir: s.Dir, Command: s.Command, Label: s.Label, Worktree: res.Worktree, Loop: s.Loop}
+		if res.Profile != nil {
+			base.Profile = res.Profile.Name
+		}
+		// Detection only works on Windows; elsewhere the terminal decides
+		// where the windows go
+		monitors, err := monitor.Detect()
+		detected := err == nil && len(monitors) > 0
+		idx := 0
+		if detected {
+			x, y, ok := currentWindowCenter()
+			idx = monitorAt(monitors, x, y, ok)
+		}
+		configs, err := projectConfigs(cfg, base, append([]string{res.Project}, projects...), NewRunID(), idx)
+		if err != nil {
+			return err
+		}
+
+		var results []LaunchResult
+		if detected {
+			layoutOn(&monitors[idx], monitorLayout(cfg, idx), configs)
+			results = LaunchAllWithCurrent(context.Background(), l, configs).Results[1:]
+		} else {
+			for _, c := range configs[1:] {
+				results = append(results, LaunchResult{Title: c.Title, Err: l.NewWindow(SpecFor(c))})
+			}
+		}
+
+		var errs []error
+		for _, r := range results {
+			if r.Err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.Title, r.Err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// projectConfigs returns a launch config per project, each based on base
+// and titled the way the picker would title it, tagged for run runID on
+// monitor mon. The first is the current window.
+func projectConfigs(cfg *config.Config, base LaunchConfig, projects []string, runID string, mon int) ([]LaunchConfig, error) {
+	tmpl, err := ParseTitleTemplate(cfg.TitleTemplate)
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]LaunchConfig, len(projects))
+	for i, p := range projects {
+		f := TitleFields{Tool: base.Label, Project: p}
+		if cfg.HasProfiles() {
+			f.Profile = base.Profile
+		}
+		c := base
+		c.Project = p
+		title, err := tmpl.Render(f)
+		if err != nil {
+			return nil, err
+		}
+		// Tagged so an older window with the same title isn't placed instead
+		c.Title = title + " " + RunTag(runID, mon, i)
+		c.PickedTitle = c.Title
+		configs[i] = c
+	}
+	return configs, nil
+}
+
+// layoutOn positions configs on mon with layout, falling back to a grid
+// when the layout holds fewer windows
+func layoutOn(mon *monitor.Monitor, layout string, configs []LaunchConfig) {
+	positions := CalculateLayout(mon, len(configs), layout)
+	if len(positions) < len(configs) {
+		positions = calculateGrid(mon, len(configs))
+	}
+	for i, pos := range positions {
+		configs[i].X, configs[i].Y = pos.X, pos.Y
+		configs[i].Width, configs[i].Height = pos.Width, pos.Height
+	}
+}
+
+// monitorLayout returns the configured layout of the monitor at idx, or
+// grid when it has none
+func monitorLayout(cfg *config.Config, idx int) string {
+	if idx < len(cfg.Monitors) && cfg.Monitors[idx].Layout != "" {
+		return cfg.Monitors[idx].Layout
+	}
+	return "grid"
+}
+
+// monitorAt returns the index of the monitor holding the point x, y, or of
+// the primary monitor when there is no point or no monitor holds it
+func monitorAt(monitors []monitor.Monitor, x, y int, ok bool) int {
+	primary := 0
+	for i, m := range monitors {
+		if ok && x >= m.X && x < m.X+m.Width && y >= m.Y && y < m.Y+m.Height {
+			return i
+		}
+		if m.Primary {
+			primary = i
+		}
+	}
+	return primary
+}
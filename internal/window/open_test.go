@@ -0,0 +1,63 @@
+package window
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/monitor"
+)
+
+func TestProjectConfigs(t *testing.T) {
+	cfg := &config.Config{Profiles: []config.Profile{{Name: "work"}, {Name: "home"}}}
+	base := LaunchConfig{WorkingDir: "/src", Command: "claude", Label: "cc", Profile: "work", Worktree: true, Loop: true}
+	configs, err := projectConfigs(cfg, base, []string{"api", "web"}, "ab12cd", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 || configs[0].Project != "api" || configs[1].Project != "web" {
+		t.Fatalf("unexpected configs %+v", configs)
+	}
+	if c := configs[1]; c.Title != "web · cc · work #ab12cd.2.2" || c.PickedTitle != c.Title {
+		t.Errorf("unexpected title %q (picked %q)", c.Title, c.PickedTitle)
+	}
+
+	argv := strings.Join(pickerArgv(configs[1]), " ")
+	if !strings.HasSuffix(argv, "--project web --profile work --worktree --loop") {
+		t.Errorf("expected the picker to be skipped, got %q", argv)
+	}
+}
+
+func TestLayoutOn(t *testing.T) {
+	mon := &monitor.Monitor{X: 100, Width: 1000, Height: 800}
+	configs := make([]LaunchConfig, 2)
+	layoutOn(mon, "vertical", configs)
+	if configs[1].X != 600 || configs[1].Width != 500 || configs[1].Height != 800 {
+		t.Errorf("unexpected vertical layout %+v", configs)
+	}
+
+	// A full screen layout has room for one window, so several share a grid
+	configs = make([]LaunchConfig, 3)
+	layoutOn(mon, "full", configs)
+	got := []int{configs[0].X, configs[1].X, configs[2].Y}
+	if !reflect.DeepEqual(got, []int{100, 600, 400}) {
+		t.Errorf("expected a grid, got %+v", configs)
+	}
+}
+
+func TestMonitorAt(t *testing.T) {
+	monitors := []monitor.Monitor{
+		{X: -1920, Width: 1920, Height: 1080},
+		{X: 0, Width: 2560, Height: 1440, Primary: true},
+	}
+	if got := monitorAt(monitors, -500, 300, true); got != 0 {
+		t.Errorf("expected the left monitor, got %d", got)
+	}
+	if got := monitorAt(monitors, 0, 0, false); got != 1 {
+		t.Errorf("expected the primary monitor without a window, got %d", got)
+	}
+	if got := monitorAt(monitors, 9999, 0, true); got != 1 {
+		t.Errorf("expected the primary monitor off screen, got %d", got)
+	}
+}
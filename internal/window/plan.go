@@ -0,0 +1,108 @@
+package window
+
+import (
+	"reflect"
+
+	"github.com/bcmister/cc/internal/monitor"
+)
+
+// Plan is a launch worked out without side effects: every window, where it
+// goes, what runs in it and the terminal commands that open it. It
+// round-trips through JSON so a plan can be reviewed, saved and applied later.
+// Applying reads only Groups; Windows and Commands say what they launch.
+type Plan struct {
+	RunID    string
+	Terminal string // launcher name
+	Monitors []monitor.Monitor
+	Groups   []Group
+	Windows  []PlannedWindow
+	// Commands are the terminal command lines in launch order; empty when
+	// the launcher cannot describe them
+	Commands [][]string
+}
+
+// PlannedWindow is one terminal of a plan
+type PlannedWindow struct {
+	Title    string
+	Monitor  int  // zero-based monitor index
+	Index    int  // zero-based window index on the monitor
+	Current  bool // runs in the terminal cc was started from
+	Position Position
+	Argv     []string // picker command line
+}
+
+// NewPlan describes launching groups through l. Account profiles are read
+// from the config by the picker itself, so plans never hold API keys.
+func NewPlan(l Launcher, runID string, monitors []monitor.Monitor, groups []Group) *Plan {
+	p := &Plan{
+		RunID:    runID,
+		Terminal: l.Name(),
+		Monitors: monitors,
+		Groups:   groups,
+	}
+
+	_, grouped := l.(GroupLauncher)
+	var rest []Spec
+	for _, g := range groups {
+		for j, cfg := range g.Configs {
+			current := !grouped && len(p.Windows) == 0
+			if !current {
+				rest = append(rest, SpecFor(cfg))
+			}
+			p.Windows = append(p.Windows, PlannedWindow{
+				Title:    cfg.Title,
+				Monitor:  g.Monitor,
+				Index:    j,
+				Current:  current,
+				Position: Position{X: cfg.X, Y: cfg.Y, Width: cfg.Width, Height: cfg.Height},
+				Argv:     pickerArgv(cfg),
+			})
+		}
+	}
+
+	switch d := l.(type) {
+	case GroupDescriber:
+		p.Commands = d.DescribeGroups(groups)
+	case Describer:
+		for _, spec := range rest {
+			p.Commands = append(p.Commands, d.DescribeWindow(spec))
+		}
+	}
+	return p
+}
+
+// Edited reports whether p's Windows or Commands differ from what its
+// Groups launch through l, as they do once the plan file is edited by hand
+// or the terminal is set up differently
+func (p *Plan) Edited(l Launcher) bool {
+	fresh := NewPlan(l, p.RunID, p.Monitors, p.Groups)
+	return !reflect.DeepEqual(fresh.Windows, p.Windows) || !reflect.DeepEqual(fresh.Commands, p.Commands)
+}
+
+// Rerun returns p as a new run with ID runID through l. Titles carry a run
+// tag for the new ID in place of the old, so a plan applied twice opens two
+// sets of windows that can still be told apart.
+func (p *Plan) Rerun(l Launcher, runID string) *Plan {
+	groups := make([]Group, len(p.Groups))
+	for i, g := range p.Groups {
+		g.Configs = append([]LaunchConfig(nil), g.Configs...)
+		for j := range g.Configs {
+			c := &g.Configs[j]
+			tag := RunTag(runID, g.Monitor, j)
+			c.Title = retag(c.Title, tag)
+			if c.PickedTitle != "" {
+				c.PickedTitle = retag(c.PickedTitle, tag)
+			}
+		}
+		groups[i] = g
+	}
+	return NewPlan(l, runID, p.Monitors, groups)
+}
+
+// retag replaces the run tag at the end of title with tag
+func retag(title, tag string) string {
+	if old := TitleTag(title); old != "" {
+		title = title[:len(title)-len(old)-1]
+	}
+	return title + " " + tag
+}
@@ -0,0 +1,146 @@
+package window
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcmister/cc/internal/config"
+	"github.com/bcmister/cc/internal/monitor"
+)
+
+func planGroups() []Group {
+	profiles := []config.Profile{{Name: "work", ConfigDir: "/w", APIKey: "sk-secret"}, {Name: "home", ConfigDir: "/h"}}
+	cfg := func(title string, x int) LaunchConfig {
+		return LaunchConfig{Title: title, WorkingDir: "/projects", X: x, Width: 960, Height: 1080,
+			Command: "claude", Label: "cc", Profiles: profiles}
+	}
+	return []Group{
+		{Monitor: 0, Layout: "full", Configs: []LaunchConfig{cfg("cc-1-1 #ab.1.1", 0)}},
+		{Monitor: 1, Layout: "vertical", Configs: []LaunchConfig{cfg("cc-2-1 #ab.2.1", 1920), cfg("cc-2-2 #ab.2.2", 2880)}},
+	}
+}
+
+func TestNewPlanForWindowsTerminal(t *testing.T) {
+	plan := NewPlan(&WindowsTerminal{}, "ab", []monitor.Monitor{{Name: "1"}, {Name: "2"}}, planGroups())
+
+	if plan.Terminal != "wt" || len(plan.Windows) != 3 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	if !plan.Windows[0].Current || plan.Windows[1].Current {
+		t.Errorf("expected only the first window to run in the current terminal")
+	}
+	if got := plan.Windows[2].Position; got != (Position{X: 2880, Width: 960, Height: 1080}) {
+		t.Errorf("unexpected position %+v", got)
+	}
+
+	// The current terminal needs no wt command
+	if len(plan.Commands) != 2 {
+		t.Fatalf("expected 2 wt commands, got %d", len(plan.Commands))
+	}
+	cmd := plan.Commands[0]
+	if cmd[0] != "wt" || cmd[2] != "cc-2-1 #ab.2.1" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+
+	// Each window runs the picker for its own config
+	w := plan.Windows[1]
+	if w.Argv[1] != "pick" || !contains(w.Argv, "/projects") {
+		t.Errorf("unexpected picker argv %q", w.Argv)
+	}
+	if !contains(cmd, "pick") {
+		t.Errorf("wt command should run the picker, got %q", cmd)
+	}
+}
+
+func TestNewPlanHasNoKeys(t *testing.T) {
+	plan := NewPlan(&WindowsTerminal{}, "ab", []monitor.Monitor{{}, {}}, planGroups())
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "sk-secret") {
+		t.Errorf("plan leaks an API key")
+	}
+}
+
+func contains(argv []string, s string) bool {
+	for _, a := range argv {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewPlanRoundTrip(t *testing.T) {
+	plan := NewPlan(&WindowsTerminalPanes{}, "ab", []monitor.Monitor{{Name: "1", Width: 1920}, {Name: "2"}}, planGroups())
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Plan
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.RunID != "ab" || got.Terminal != "wt-panes" || got.Monitors[0].Width != 1920 {
+		t.Errorf("unexpected plan after round trip %+v", got)
+	}
+	if len(got.Groups) != 2 || got.Groups[1].Configs[1].Title != "cc-2-2 #ab.2.2" {
+		t.Errorf("groups lost in round trip: %+v", got.Groups)
+	}
+	for _, w := range got.Windows {
+		if w.Current {
+			t.Errorf("%s: group launchers have no current-terminal window", w.Title)
+		}
+	}
+	if len(got.Commands) != 2 {
+		t.Errorf("expected one wt command per monitor, got %d", len(got.Commands))
+	}
+}
+
+func TestPlanRerun(t *testing.T) {
+	plan := NewPlan(&WindowsTerminal{}, "ab", []monitor.Monitor{{}, {}}, planGroups())
+	plan.Groups[1].Configs[1].PickedTitle = "cc-2-2 " + ProjectPlaceholder + " #ab.2.2"
+	// A run ID can turn up elsewhere in a title too
+	plan.Groups[0].Configs[0].Title = "ab-notes #ab.1.1"
+
+	again := plan.Rerun(&WindowsTerminal{}, "cd")
+	if again.RunID != "cd" {
+		t.Errorf("expected run ID cd, got %s", again.RunID)
+	}
+	c := again.Groups[1].Configs[1]
+	if c.Title != "cc-2-2 #cd.2.2" || c.PickedTitle != "cc-2-2 "+ProjectPlaceholder+" #cd.2.2" {
+		t.Errorf("unexpected titles %q, %q", c.Title, c.PickedTitle)
+	}
+	if got := again.Groups[0].Configs[0].Title; got != "ab-notes #cd.1.1" {
+		t.Errorf("expected only the run tag to change, got %q", got)
+	}
+	if got := again.Windows[2].Title; got != "cc-2-2 #cd.2.2" {
+		t.Errorf("unexpected planned title %q", got)
+	}
+	if plan.Groups[1].Configs[1].Title != "cc-2-2 #ab.2.2" {
+		t.Errorf("the original plan should be left alone")
+	}
+}
+
+func TestTmuxDescribeGroups(t *testing.T) {
+	cmds := (&Tmux{Session: "dev"}).DescribeGroups(planGroups())
+
+	var verbs []string
+	for _, c := range cmds {
+		if c[0] != "tmux" {
+			t.Fatalf("unexpected command %q", c)
+		}
+		verbs = append(verbs, c[1])
+	}
+	got := strings.Join(verbs, " ")
+	if !strings.HasPrefix(got, "new-session select-pane") || !strings.Contains(got, "new-window") {
+		t.Errorf("expected a new session then a new window, got %s", got)
+	}
+	if strings.Count(got, "split-window") != 1 {
+		t.Errorf("expected one split, got %s", got)
+	}
+}
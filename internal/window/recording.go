@@ -0,0 +1,63 @@
+package window
+
+import (
+	"context"
+	"sync"
+)
+
+// Call is one launcher invocation captured by a RecordingLauncher
+type Call struct {
+	Method string // "NewWindow", "NewTab", "SplitPane", "Place" or "PlaceCurrent"
+	Spec   Spec
+}
+
+// RecordingLauncher is a Launcher and Placer that records every call
+// instead of opening terminals, for testing launch paths.
+type RecordingLauncher struct {
+	// Errors maps a spec title to the error returned for it
+	Errors map[string]error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *RecordingLauncher) Name() string { return "recording" }
+
+func (r *RecordingLauncher) NewWindow(spec Spec) error    { return r.record("NewWindow", spec) }
+func (r *RecordingLauncher) NewTab(spec Spec) error       { return r.record("NewTab", spec) }
+func (r *RecordingLauncher) SplitPane(spec Spec) error    { return r.record("SplitPane", spec) }
+func (r *RecordingLauncher) PlaceCurrent(spec Spec) error { return r.record("PlaceCurrent", spec) }
+
+// Place records one call per spec
+func (r *RecordingLauncher) Place(ctx context.Context, specs []Spec) []error {
+	errs := make([]error, len(specs))
+	for i, spec := range specs {
+		errs[i] = r.record("Place", spec)
+	}
+	return errs
+}
+
+// Calls returns the recorded calls in the order they were made
+func (r *RecordingLauncher) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to one method
+func (r *RecordingLauncher) CallsTo(method string) []Call {
+	var out []Call
+	for _, c := range r.Calls() {
+		if c.Method == method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (r *RecordingLauncher) record(method string, spec Spec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Spec: spec})
+	return r.Errors[spec.Title]
+}
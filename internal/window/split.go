@@ -0,0 +1,68 @@
+package window
+
+// Direction is the side of an existing pane that a new pane is split off to
+type Direction string
+
+const (
+	SplitRight Direction = "right"
+	SplitDown  Direction = "down"
+)
+
+// Split is one step in building a layout out of pane splits. The new pane
+// takes Size (0-1) of the parent pane's space and shows config Cell.
+type Split struct {
+	Parent    int // creation index of the pane to split; pane 0 exists before any split
+	Direction Direction
+	Size      float64
+	Cell      int // index into the group's configs
+}
+
+// SplitPlan returns the splits that arrange count panes inside one window
+// the way CalculateLayout arranges windows on a monitor. Pane 0 shows cell 0
+// and split i creates pane i+1.
+func SplitPlan(count int, layout string) []Split {
+	p := &splitPlanner{}
+	switch layout {
+	case "full":
+	case "vertical":
+		p.chain(0, count, SplitRight, func(k int) int { return k })
+	case "horizontal":
+		p.chain(0, count, SplitDown, func(k int) int { return k })
+	default:
+		// Columns first, then each column into its rows, so cell i lands
+		// at row i/cols, column i%cols
+		cols, _ := GridSize(count)
+		if cols > count {
+			cols = count
+		}
+		tops := p.chain(0, cols, SplitRight, func(k int) int { return k })
+		for c, top := range tops {
+			cells := (count - c + cols - 1) / cols
+			col := c
+			p.chain(top, cells, SplitDown, func(k int) int { return k*cols + col })
+		}
+	}
+	return p.splits
+}
+
+type splitPlanner struct {
+	splits []Split
+}
+
+// chain divides pane into n equal parts along dir, each split taking its
+// share from the pane the previous split created. cell maps a part to the
+// config it shows. It returns the panes in order, starting with pane.
+func (p *splitPlanner) chain(pane, n int, dir Direction, cell func(k int) int) []int {
+	panes := []int{pane}
+	for k := 1; k < n; k++ {
+		p.splits = append(p.splits, Split{
+			Parent:    pane,
+			Direction: dir,
+			Size:      float64(n-k) / float64(n-k+1),
+			Cell:      cell(k),
+		})
+		pane = len(p.splits)
+		panes = append(panes, pane)
+	}
+	return panes
+}
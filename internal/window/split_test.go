@@ -0,0 +1,83 @@
+package window
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bcmister/cc/internal/monitor"
+)
+
+type rectF struct{ x, y, w, h float64 }
+
+// applySplits plays a split plan on a unit-less window and returns the
+// rectangle each cell ends up in
+func applySplits(count int, splits []Split, w, h float64) map[int]rectF {
+	panes := []rectF{{0, 0, w, h}}
+	cells := []int{0}
+	for _, s := range splits {
+		parent := panes[s.Parent]
+		var keep, add rectF
+		if s.Direction == SplitRight {
+			addW := parent.w * s.Size
+			keep = rectF{parent.x, parent.y, parent.w - addW, parent.h}
+			add = rectF{parent.x + parent.w - addW, parent.y, addW, parent.h}
+		} else {
+			addH := parent.h * s.Size
+			keep = rectF{parent.x, parent.y, parent.w, parent.h - addH}
+			add = rectF{parent.x, parent.y + parent.h - addH, parent.w, addH}
+		}
+		panes[s.Parent] = keep
+		panes = append(panes, add)
+		cells = append(cells, s.Cell)
+	}
+
+	out := make(map[int]rectF)
+	for i, c := range cells {
+		out[c] = panes[i]
+	}
+	return out
+}
+
+func TestSplitPlanMatchesLayout(t *testing.T) {
+	mon := &monitor.Monitor{Width: 3600, Height: 3600}
+	tests := []struct {
+		layout string
+		count  int
+	}{
+		{"full", 1},
+		{"vertical", 2},
+		{"vertical", 3},
+		{"horizontal", 3},
+		{"grid", 2},
+		{"grid", 4},
+		{"grid", 6},
+		{"grid", 9},
+	}
+
+	for _, tt := range tests {
+		want := CalculateLayout(mon, tt.count, tt.layout)
+		got := applySplits(tt.count, SplitPlan(tt.count, tt.layout), 3600, 3600)
+		if len(got) != tt.count {
+			t.Errorf("%s/%d: expected %d panes, got %d", tt.layout, tt.count, tt.count, len(got))
+			continue
+		}
+		for i, pos := range want {
+			r := got[i]
+			if math.Abs(r.x-float64(pos.X)) > 1 || math.Abs(r.y-float64(pos.Y)) > 1 ||
+				math.Abs(r.w-float64(pos.Width)) > 1 || math.Abs(r.h-float64(pos.Height)) > 1 {
+				t.Errorf("%s/%d cell %d: got %+v, want %+v", tt.layout, tt.count, i, r, pos)
+			}
+		}
+	}
+}
+
+func TestSplitPlanUnevenGrid(t *testing.T) {
+	// Three cells: two columns, the right one a single full-height pane
+	got := applySplits(3, SplitPlan(3, "grid"), 100, 100)
+	if got[0] != (rectF{0, 0, 50, 50}) || got[2] != (rectF{0, 50, 50, 50}) {
+		t.Errorf("left column: got %+v and %+v", got[0], got[2])
+	}
+	if got[1] != (rectF{50, 0, 50, 100}) {
+		t.Errorf("right column: got %+v", got[1])
+	}
+}
@@ -0,0 +1,91 @@
+package window
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/bcmister/cc/internal/picker"
+)
+
+// DefaultTitleTemplate names windows "cc-1-2" until a project is chosen,
+// then "api-server · cc · work"
+const DefaultTitleTemplate = `{{if .Project}}{{.Project}} · {{.Tool}}{{if .Profile}} · {{.Profile}}{{end}}{{else}}{{.Tool}}-{{.Monitor}}-{{.Window}}{{end}}`
+
+// RunTag identifies one window of a run. It is appended to every title,
+// including the one the picker sets, so the window can be found again.
+func RunTag(runID string, monitor, index int) string {
+	return fmt.Sprintf("#%s.%d.%d", runID, monitor+1, index+1)
+}
+
+// TitleTag returns the run tag at the end of title, or "" when it has none
+func TitleTag(title string) string {
+	i := strings.LastIndex(title, " #")
+	if i < 0 || strings.Contains(title[i+2:], " ") {
+		return ""
+	}
+	return title[i+1:]
+}
+
+// RunIDOf returns the run ID in title's run tag, or "" when it has none
+func RunIDOf(title string) string {
+	parts := strings.Split(strings.TrimPrefix(TitleTag(title), "#"), ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return ""
+	}
+	for _, n := range parts[1:] {
+		if _, err := strconv.Atoi(n); err != nil {
+			return ""
+		}
+	}
+	return parts[0]
+}
+
+// Placeholders rendered into a picked title and substituted by the picker
+// once the project and account are known
+const (
+	ProjectPlaceholder = picker.ProjectPlaceholder
+	ProfilePlaceholder = picker.ProfilePlaceholder
+)
+
+// TitleFields are the values available to a title template
+type TitleFields struct {
+	Monitor      string // monitor nickname, or its number when it has none
+	MonitorIndex int    // 1-based monitor number
+	Window       int    // 1-based window number on the monitor
+	Tool         string // "cc" or "cx"
+	Profile      string // chosen account; empty until picked
+	Project      string // chosen project; empty until picked
+	RunID        string // ID shared by every window of one launch
+}
+
+// TitleTemplate renders window titles from a text/template
+type TitleTemplate struct {
+	tmpl *template.Template
+}
+
+// ParseTitleTemplate parses text, or DefaultTitleTemplate when text is empty
+func ParseTitleTemplate(text string) (*TitleTemplate, error) {
+	if text == "" {
+		text = DefaultTitleTemplate
+	}
+	tmpl, err := template.New("title").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid titleTemplate: %w", err)
+	}
+	// Catch references to unknown fields now rather than at launch
+	if _, err := (&TitleTemplate{tmpl}).Render(TitleFields{Project: "p", Profile: "p"}); err != nil {
+		return nil, err
+	}
+	return &TitleTemplate{tmpl: tmpl}, nil
+}
+
+// Render executes the template, collapsing the result onto one line
+func (t *TitleTemplate) Render(f TitleFields) (string, error) {
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, f); err != nil {
+		return "", fmt.Errorf("invalid titleTemplate: %w", err)
+	}
+	return strings.Join(strings.Fields(b.String()), " "), nil
+}
@@ -0,0 +1,68 @@
+package window
+
+import "testing"
+
+func TestDefaultTitleTemplate(t *testing.T) {
+	tmpl, err := ParseTitleTemplate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fields TitleFields
+		want   string
+	}{
+		{TitleFields{Tool: "cc", Monitor: "1", Window: 2}, "cc-1-2"},
+		{TitleFields{Tool: "cc", Monitor: "left", Window: 1}, "cc-left-1"},
+		{TitleFields{Tool: "cc", Project: "api-server", Profile: "work"}, "api-server · cc · work"},
+		{TitleFields{Tool: "cx", Project: "api-server"}, "api-server · cx"},
+	}
+	for _, tt := range tests {
+		got, err := tmpl.Render(tt.fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Render(%+v) = %q, want %q", tt.fields, got, tt.want)
+		}
+	}
+}
+
+func TestParseTitleTemplateErrors(t *testing.T) {
+	for _, text := range []string{"{{.Tool", "{{.Missing}}"} {
+		if _, err := ParseTitleTemplate(text); err == nil {
+			t.Errorf("expected error for %q", text)
+		}
+	}
+}
+
+func TestTitleTag(t *testing.T) {
+	tests := map[string]string{
+		"cc-1-2 #ab12cd.1.2":          "#ab12cd.1.2",
+		"api · cc · work #ab12cd.1.2": "#ab12cd.1.2",
+		"Windows PowerShell":          "",
+		"issue #12 fix":               "",
+		RunTag("ab12cd", 0, 1):        "",
+		"x " + RunTag("ab12cd", 1, 0): "#ab12cd.2.1",
+	}
+	for title, want := range tests {
+		if got := TitleTag(title); got != want {
+			t.Errorf("TitleTag(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestRunIDOf(t *testing.T) {
+	tests := map[string]string{
+		"cc-1-2 #ab12cd.1.2":          "ab12cd",
+		"api · cc · work #ff00aa.3.1": "ff00aa",
+		"cc-1-2 #ab12cd":              "",
+		"issue #12.x.y":               "",
+		"Windows PowerShell":          "",
+	}
+	for title, want := range tests {
+		if got := RunIDOf(title); got != want {
+			t.Errorf("RunIDOf(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
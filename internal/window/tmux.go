@@ -0,0 +1,232 @@
+package window
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultTmuxSession is the session name used when the config does not set one
+const DefaultTmuxSession = "cc"
+
+// Tmux launches terminals as panes of a tmux session. Each monitor group
+// becomes one tmux window whose panes are arranged by the monitor's layout.
+type Tmux struct {
+	Session      string // session to create or join
+	JoinExisting bool   // add windows to an existing session instead of failing
+
+	// Exec runs one tmux command and returns its trimmed output.
+	// Nil means run the tmux binary.
+	Exec func(args ...string) (string, error)
+
+	ready bool // session has been created or joined
+}
+
+// tmuxLayouts maps config layout names onto tmux's preset layouts
+var tmuxLayouts = map[string]string{
+	"grid":       "tiled",
+	"vertical":   "even-horizontal", // side-by-side columns
+	"horizontal": "even-vertical",   // stacked rows
+}
+
+func (t *Tmux) Name() string { return "tmux" }
+
+func (t *Tmux) NewWindow(spec Spec) error {
+	_, err := t.newWindow(spec.Title, spec)
+	return err
+}
+
+// NewTab opens a tmux window, which is the closest thing tmux has to a tab
+func (t *Tmux) NewTab(spec Spec) error {
+	return t.NewWindow(spec)
+}
+
+func (t *Tmux) SplitPane(spec Spec) error {
+	if err := t.ensureSession(); err != nil {
+		return err
+	}
+	_, err := t.split(t.session()+":", spec)
+	return err
+}
+
+// LaunchGroup opens one tmux window for the group with a pane per config
+func (t *Tmux) LaunchGroup(g Group) error {
+	if len(g.Configs) == 0 {
+		return nil
+	}
+
+	name := fmt.Sprintf("monitor-%d", g.Monitor+1)
+	first, err := t.newWindow(name, SpecFor(g.Configs[0]))
+	if err != nil {
+		return err
+	}
+	target := first // any pane id also addresses its window
+
+	if _, err := t.tmux("set-option", "-w", "-t", target, "pane-border-status", "top"); err != nil {
+		return err
+	}
+	if _, err := t.tmux("set-option", "-w", "-t", target, "pane-border-format", " #{pane_title} "); err != nil {
+		return err
+	}
+
+	layout, ok := tmuxLayouts[g.Layout]
+	if !ok {
+		layout = "tiled"
+	}
+	for _, cfg := range g.Configs[1:] {
+		if _, err := t.split(target, SpecFor(cfg)); err != nil {
+			return err
+		}
+		// Rebalance after every split so the next one has room
+		if _, err := t.tmux("select-layout", "-t", target, layout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DescribeGroups returns the tmux commands LaunchGroup runs for groups,
+// assuming the session does not exist yet. Pane ids are numbered from %1.
+func (t *Tmux) DescribeGroups(groups []Group) [][]string {
+	var cmds [][]string
+	panes := 0
+	dry := &Tmux{Session: t.Session, Exec: func(args ...string) (string, error) {
+		if args[0] == "has-session" {
+			return "", fmt.Errorf("no session")
+		}
+		cmds = append(cmds, append([]string{"tmux"}, args...))
+		if args[0] == "new-session" || args[0] == "new-window" || args[0] == "split-window" {
+			panes++
+			return fmt.Sprintf("%%%d", panes), nil
+		}
+		return "", nil
+	}}
+	for _, g := range groups {
+		dry.LaunchGroup(g)
+	}
+	return cmds
+}
+
+// Attach joins the session from the current terminal, switching client when
+// already inside tmux
+func (t *Tmux) Attach() error {
+	verb := "attach-session"
+	if os.Getenv("TMUX") != "" {
+		verb = "switch-client"
+	}
+	cmd := exec.Command("tmux", verb, "-t", t.session())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// JoinCurrent makes t add its windows to the session the current terminal
+// is in, when that is inside tmux
+func (t *Tmux) JoinCurrent() error {
+	if os.Getenv("TMUX") == "" {
+		return nil
+	}
+	args := []string{"display-message", "-p"}
+	if pane := os.Getenv("TMUX_PANE"); pane != "" {
+		args = append(args, "-t", pane)
+	}
+	name, err := t.tmux(append(args, "#S")...)
+	if err != nil {
+		return err
+	}
+	t.Session, t.JoinExisting = name, true
+	return nil
+}
+
+// newWindow creates a tmux window running spec, starting or joining the
+// session on first use, and returns the id of its pane
+func (t *Tmux) newWindow(name string, spec Spec) (string, error) {
+	verb := []string{"new-window", "-t", t.session() + ":"}
+	if !t.ready {
+		_, err := t.tmux("has-session", "-t", "="+t.session())
+		exists := err == nil
+		if exists && !t.JoinExisting {
+			return "", fmt.Errorf("tmux session %q already exists (set tmux.attach to add windows to it)", t.session())
+		}
+		if !exists {
+			verb = []string{"new-session", "-d", "-s", t.session()}
+		}
+		t.ready = true
+	}
+
+	args := append(verb, "-n", name, "-c", spec.Dir, "-P", "-F", "#{pane_id}")
+	args = append(args, envArgs(spec.Env)...)
+	pane, err := t.tmux(append(args, shellCommand(spec.Argv))...)
+	if err != nil {
+		return "", err
+	}
+	return pane, t.setTitle(pane, spec.Title)
+}
+
+func (t *Tmux) ensureSession() error {
+	if t.ready {
+		return nil
+	}
+	if _, err := t.tmux("has-session", "-t", "="+t.session()); err != nil {
+		return fmt.Errorf("tmux session %q is not running", t.session())
+	}
+	t.ready = true
+	return nil
+}
+
+func (t *Tmux) split(target string, spec Spec) (string, error) {
+	args := []string{"split-window", "-t", target, "-c", spec.Dir, "-P", "-F", "#{pane_id}"}
+	args = append(args, envArgs(spec.Env)...)
+	pane, err := t.tmux(append(args, shellCommand(spec.Argv))...)
+	if err != nil {
+		return "", err
+	}
+	return pane, t.setTitle(pane, spec.Title)
+}
+
+func (t *Tmux) setTitle(pane, title string) error {
+	if title == "" {
+		return nil
+	}
+	_, err := t.tmux("select-pane", "-t", pane, "-T", title)
+	return err
+}
+
+func (t *Tmux) session() string {
+	if t.Session == "" {
+		return DefaultTmuxSession
+	}
+	return t.Session
+}
+
+func (t *Tmux) tmux(args ...string) (string, error) {
+	if t.Exec != nil {
+		return t.Exec(args...)
+	}
+	out, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("tmux %s: %s", args[0], strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// envArgs converts extra environment into tmux -e flags
+func envArgs(env map[string]string) []string {
+	var args []string
+	for k, v := range env {
+		args = append(args, "-e", k+"="+v)
+	}
+	return args
+}
+
+// shellCommand joins argv into a single POSIX shell command line, which is
+// how tmux expects the command for a new window or pane
+func shellCommand(argv []string) string {
+	quoted := make([]string, len(argv))
+	for i, a := range argv {
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
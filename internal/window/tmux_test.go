@@ -0,0 +1,145 @@
+package window
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeTmux records tmux invocations and hands out sequential pane ids
+type fakeTmux struct {
+	sessionExists bool
+	calls         [][]string
+	panes         int
+}
+
+func (f *fakeTmux) exec(args ...string) (string, error) {
+	f.calls = append(f.calls, args)
+	switch args[0] {
+	case "has-session":
+		if !f.sessionExists {
+			return "", errors.New("no session")
+		}
+	case "display-message":
+		return "work", nil
+	case "new-session", "new-window", "split-window":
+		f.panes++
+		return fmt.Sprintf("%%%d", f.panes), nil
+	}
+	return "", nil
+}
+
+func (f *fakeTmux) verbs() []string {
+	var out []string
+	for _, c := range f.calls {
+		out = append(out, c[0])
+	}
+	return out
+}
+
+func (f *fakeTmux) find(verb string) [][]string {
+	var out [][]string
+	for _, c := range f.calls {
+		if c[0] == verb {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func tmuxGroup(layout string, titles ...string) Group {
+	g := Group{Monitor: 1, Layout: layout}
+	for _, title := range titles {
+		g.Configs = append(g.Configs, LaunchConfig{Title: title, WorkingDir: "/projects", Command: "claude", Label: "cc"})
+	}
+	return g
+}
+
+func TestTmuxLaunchGroupCreatesSession(t *testing.T) {
+	f := &fakeTmux{}
+	tm := &Tmux{Session: "work", Exec: f.exec}
+
+	if err := tm.LaunchGroup(tmuxGroup("vertical", "cc-2-1", "cx-2-2")); err != nil {
+		t.Fatalf("LaunchGroup failed: %v", err)
+	}
+
+	sessions := f.find("new-session")
+	if len(sessions) != 1 {
+		t.Fatalf("expected a new session, got calls %v", f.verbs())
+	}
+	if !strings.Contains(strings.Join(sessions[0], " "), "-s work -n monitor-2") {
+		t.Errorf("unexpected new-session args %v", sessions[0])
+	}
+
+	if splits := f.find("split-window"); len(splits) != 1 {
+		t.Errorf("expected 1 split, got %d", len(splits))
+	}
+
+	titles := f.find("select-pane")
+	if len(titles) != 2 || titles[0][4] != "cc-2-1" || titles[1][4] != "cx-2-2" {
+		t.Errorf("expected pane titles cc-2-1 and cx-2-2, got %v", titles)
+	}
+
+	layouts := f.find("select-layout")
+	if len(layouts) != 1 || layouts[0][3] != "even-horizontal" {
+		t.Errorf("expected vertical layout to map to even-horizontal, got %v", layouts)
+	}
+
+	// A second group becomes another window in the same session
+	if err := tm.LaunchGroup(tmuxGroup("grid", "cc-3-1")); err != nil {
+		t.Fatalf("second LaunchGroup failed: %v", err)
+	}
+	if windows := f.find("new-window"); len(windows) != 1 || windows[0][2] != "work:" {
+		t.Errorf("expected second group in session work, got %v", windows)
+	}
+}
+
+func TestTmuxExistingSession(t *testing.T) {
+	f := &fakeTmux{sessionExists: true}
+	tm := &Tmux{Exec: f.exec}
+	if err := tm.LaunchGroup(tmuxGroup("grid", "cc-1-1")); err == nil {
+		t.Errorf("expected error when session exists and attach is off")
+	}
+
+	f = &fakeTmux{sessionExists: true}
+	tm = &Tmux{JoinExisting: true, Exec: f.exec}
+	if err := tm.LaunchGroup(tmuxGroup("grid", "cc-1-1")); err != nil {
+		t.Fatalf("LaunchGroup failed: %v", err)
+	}
+	if len(f.find("new-session")) != 0 || len(f.find("new-window")) != 1 {
+		t.Errorf("expected a window added to the existing session, got calls %v", f.verbs())
+	}
+}
+
+func TestTmuxJoinCurrent(t *testing.T) {
+	t.Setenv("TMUX", "")
+	f := &fakeTmux{sessionExists: true}
+	tm := &Tmux{Exec: f.exec}
+	if err := tm.JoinCurrent(); err != nil || tm.Session != "" || len(f.calls) != 0 {
+		t.Errorf("expected nothing outside tmux, got %+v, %v", tm, err)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	t.Setenv("TMUX_PANE", "%3")
+	if err := tm.JoinCurrent(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.NewWindow(Spec{Title: "web", Dir: "/projects", Argv: []string{"cc"}}); err != nil {
+		t.Fatalf("expected a window in the current session, got %v", err)
+	}
+	if windows := f.find("new-window"); len(windows) != 1 || windows[0][2] != "work:" {
+		t.Errorf("expected the window in session work, got %v", f.calls)
+	}
+	if msg := f.find("display-message"); len(msg) != 1 || msg[0][3] != "%3" {
+		t.Errorf("expected the current pane's session to be asked for, got %v", msg)
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	got := shellCommand([]string{"pwsh", "-Command", "it's"})
+	want := `'pwsh' '-Command' 'it'\''s'`
+	if got != want {
+		t.Errorf("shellCommand = %s, want %s", got, want)
+	}
+}
@@ -1,36 +1,13 @@
 package window
 
 import (
-	"encoding/base64"
-	"fmt"
+	"context"
 	"os"
-	"os/exec"
-	"strings"
 	"sync"
-	"syscall"
-	"time"
-	"unicode/utf16"
-	"unsafe"
 
 	"github.com/bcmister/cc/internal/config"
 	"github.com/bcmister/cc/internal/monitor"
-)
-
-var (
-	user32             = syscall.NewLazyDLL("user32.dll")
-	procFindWindowW    = user32.NewProc("FindWindowW")
-	procSetWindowPos   = user32.NewProc("SetWindowPos")
-	procEnumWindows    = user32.NewProc("EnumWindows")
-	procGetWindowTextW = user32.NewProc("GetWindowTextW")
-
-	kernel32             = syscall.NewLazyDLL("kernel32.dll")
-	procGetConsoleWindow = kernel32.NewProc("GetConsoleWindow")
-)
-
-const (
-	SWP_NOZORDER   = 0x0004
-	SWP_SHOWWINDOW = 0x0040
-	HWND_TOP       = 0
+	"github.com/bcmister/cc/internal/picker"
 )
 
 // Position represents a window position and size
@@ -51,7 +28,19 @@ type LaunchConfig struct {
 	Height     int
 	Command    string           // e.g. "claude --dangerously-skip-permissions"
 	Label      string           // e.g. "cc" or "cx"
-	Profiles   []config.Profile // account profiles for picker
+	Profiles   []config.Profile `json:"-"` // account profiles for picker; never written to plans
+	// PickedTitle replaces Title once a project is chosen; it may contain
+	// ProjectPlaceholder and ProfilePlaceholder
+	PickedTitle string
+	// Project skips the picker and starts straight in this project, with
+	// the named Profile and in a new worktree when Worktree is set
+	Project  string `json:",omitempty"`
+	Profile  string `json:",omitempty"`
+	Worktree bool   `json:",omitempty"`
+	// Loop brings the picker back once the tool exits
+	Loop bool `json:",omitempty"`
+	// CdFile receives the chosen project's directory; see picker.CdFileEnv
+	CdFile string `json:"-"`
 }
 
 // LaunchResult holds the outcome of a terminal launch
@@ -60,6 +49,19 @@ type LaunchResult struct {
 	Err   error
 }
 
+// Layouts are the layout names CalculateLayout understands
+var Layouts = []string{"full", "vertical", "horizontal", "grid"}
+
+// IsLayout reports whether name is one of Layouts
+func IsLayout(name string) bool {
+	for _, l := range Layouts {
+		if l == name {
+			return true
+		}
+	}
+	return false
+}
+
 // CalculateLayout calculates window positions based on layout type
 func CalculateLayout(mon *monitor.Monitor, count int, layout string) []Position {
 	switch layout {
@@ -81,11 +83,10 @@ func CalculateLayout(mon *monitor.Monitor, count int, layout string) []Position
 	}
 }
 
-func calculateGrid(mon *monitor.Monitor, count int) []Position {
-	positions := make([]Position, count)
-
-	cols := 1
-	rows := 1
+// GridSize returns the columns and rows the grid layout uses for count windows
+func GridSize(count int) (cols, rows int) {
+	cols = 1
+	rows = 1
 	for cols*rows < count {
 		if cols <= rows {
 			cols++
@@ -93,6 +94,12 @@ func calculateGrid(mon *monitor.Monitor, count int) []Position {
 			rows++
 		}
 	}
+	return cols, rows
+}
+
+func calculateGrid(mon *monitor.Monitor, count int) []Position {
+	positions := make([]Position, count)
+	cols, rows := GridSize(count)
 
 	cellWidth := mon.Width / cols
 	cellHeight := mon.Height / rows
@@ -143,420 +150,116 @@ func calculateHorizontal(mon *monitor.Monitor, count int) []Position {
 	return positions
 }
 
-// encodePS converts a PowerShell script to a base64 UTF-16LE encoded string
-func encodePS(script string) string {
-	u16 := utf16.Encode([]rune(script))
-	b := make([]byte, len(u16)*2)
-	for i, r := range u16 {
-		b[i*2] = byte(r)
-		b[i*2+1] = byte(r >> 8)
+// pickerArgv returns the command line that runs the project picker for cfg:
+// this executable's hidden pick command
+func pickerArgv(cfg LaunchConfig) []string {
+	argv := []string{executable(), "pick", "--dir", cfg.WorkingDir, "--command", cfg.Command, "--label", cfg.Label}
+	if cfg.PickedTitle != "" {
+		argv = append(argv, "--title", cfg.PickedTitle)
+	}
+	if cfg.Project != "" {
+		argv = append(argv, "--project", cfg.Project)
+	}
+	if cfg.Profile != "" {
+		argv = append(argv, "--profile", cfg.Profile)
 	}
-	return base64.StdEncoding.EncodeToString(b)
+	if cfg.Worktree {
+		argv = append(argv, "--worktree")
+	}
+	if cfg.Loop {
+		argv = append(argv, "--loop")
+	}
+	return argv
 }
 
-// LaunchAll launches all terminals in parallel and positions them.
+// executable returns the path of the running cc binary
+func executable() string {
+	if exe, err := os.Executable(); err == nil {
+		return exe
+	}
+	return "cc"
+}
+
+// LaunchAll launches all terminals and positions them once they appear.
 // Each config carries its own Command and Label.
-func LaunchAll(configs []LaunchConfig) []LaunchResult {
+func LaunchAll(ctx context.Context, l Launcher, configs []LaunchConfig) []LaunchResult {
 	results := make([]LaunchResult, len(configs))
 
-	scripts := make([]string, len(configs))
+	specs := make([]Spec, len(configs))
 	for i, cfg := range configs {
-		scripts[i] = buildPickerScript(cfg.WorkingDir, cfg.Command, cfg.Label, cfg.Profiles)
+		specs[i] = SpecFor(cfg)
 		results[i].Title = cfg.Title
 	}
 
-	// Phase 1: Launch all wt processes as fast as possible
-	for i, cfg := range configs {
-		encoded := encodePS(scripts[i])
-		args := []string{
-			"--title", cfg.Title,
-			"-d", cfg.WorkingDir,
-			"powershell", "-NoExit", "-EncodedCommand", encoded,
-		}
-		cmd := exec.Command("wt", args...)
-		if err := cmd.Start(); err != nil {
-			results[i].Err = fmt.Errorf("failed to launch: %w", err)
+	// Phase 1: Launch all terminals as fast as possible
+	for i, spec := range specs {
+		if err := l.NewWindow(spec); err != nil {
+			results[i].Err = err
 		}
 	}
 
-	// Phase 2: Wait once for windows to start appearing, then find and position all in parallel
-	time.Sleep(300 * time.Millisecond)
-
-	var wg sync.WaitGroup
-	for i, cfg := range configs {
-		if results[i].Err != nil {
-			continue
-		}
-		wg.Add(1)
-		go func(idx int, c LaunchConfig) {
-			defer wg.Done()
-			hwnd, err := findWindowByTitle(c.Title)
-			if err != nil {
-				results[idx].Err = fmt.Errorf("failed to find window: %w", err)
-				return
-			}
-			if err := setWindowPosition(hwnd, c.X, c.Y, c.Width, c.Height); err != nil {
-				results[idx].Err = fmt.Errorf("failed to position: %w", err)
-			}
-		}(i, cfg)
+	// Phase 2: Wait for the windows to appear and position them
+	if p, ok := l.(Placer); ok {
+		positionAll(ctx, p, specs, results)
 	}
-	wg.Wait()
 
 	return results
 }
 
-// buildProfileArrays generates PowerShell array literals for profile names, dirs, and keys
-func buildProfileArrays(profiles []config.Profile) (names, dirs, keys string) {
-	if len(profiles) == 0 {
-		return "@()", "@()", "@()"
-	}
-	nameList := make([]string, len(profiles))
-	dirList := make([]string, len(profiles))
-	keyList := make([]string, len(profiles))
-	for i, p := range profiles {
-		nameList[i] = "'" + strings.ReplaceAll(p.Name, "'", "''") + "'"
-		expanded := config.ExpandPath(p.ConfigDir)
-		dirList[i] = "'" + strings.ReplaceAll(expanded, "'", "''") + "'"
-		keyList[i] = "'" + strings.ReplaceAll(p.APIKey, "'", "''") + "'"
-	}
-	return "@(" + strings.Join(nameList, ",") + ")",
-		"@(" + strings.Join(dirList, ",") + ")",
-		"@(" + strings.Join(keyList, ",") + ")"
-}
-
-func buildPickerScript(workingDir, command, label string, profiles []config.Profile) string {
-	profileNames, profileDirs, profileKeys := buildProfileArrays(profiles)
-	return `
-$R   = [char]27 + '[0m'
-$DIM = [char]27 + '[90m'
-$CYN = [char]27 + '[96m'
-$WHT = [char]27 + '[97m'
-$GRN = [char]27 + '[92m'
-$YEL = [char]27 + '[93m'
-$RED = [char]27 + '[91m'
-$INV = [char]27 + '[7m'
-$HID = [char]27 + '[?25l'
-$SHW = [char]27 + '[?25h'
-
-$profileNames = ` + profileNames + `
-$profileDirs  = ` + profileDirs + `
-$profileKeys  = ` + profileKeys + `
-
-$d = '` + workingDir + `'
-$all = @(Get-ChildItem $d -Directory | Select-Object -ExpandProperty Name)
-
-if ($all.Count -eq 0) {
-    Write-Host ""
-    Write-Host "  ${RED}No projects in $d${R}"
-    Write-Host ""
-    Read-Host "  Press Enter"
-    exit
-}
-
-$filter = ""
-$sel = 0
-$viewOffset = 0
-$maxShow = 12
-
-function Draw {
-    param($items, $sel, $filter, $startY, $viewOffset)
-
-    $termH = $Host.UI.RawUI.WindowSize.Height
-    $script:maxShow = [Math]::Max(1, $termH - $startY - 4)
-
-    $maxOff = [Math]::Max(0, $items.Count - $script:maxShow)
-    if ($viewOffset -gt $maxOff) { $viewOffset = $maxOff }
-    $script:viewOffset = $viewOffset
-
-    $row = $startY + 1
-    Write-Host "$([char]27)[${row};1H" -NoNewline
-
-    if ($filter -eq "") {
-        Write-Host "  ${CYN}>${R} ${DIM}type to filter...${R}                    " -NoNewline
-    } else {
-        Write-Host "  ${CYN}>${R} ${WHT}$filter${R}                              " -NoNewline
-    }
-    Write-Host ""
-    Write-Host "  ${DIM}─────────────────────────────────${R}      "
-
-    for ($i = 0; $i -lt $script:maxShow; $i++) {
-        $itemIdx = $viewOffset + $i
-        if ($itemIdx -lt $items.Count) {
-            $name = $items[$itemIdx]
-            if ($itemIdx -eq $sel) {
-                Write-Host "  ${INV}${CYN} > ${WHT}$name ${R}                              "
-            } else {
-                Write-Host "    ${DIM}$name${R}                                   "
-            }
-        } else {
-            Write-Host "                                          "
-        }
-    }
-
-    Write-Host ""
-    if ($items.Count -gt $script:maxShow) {
-        Write-Host "  ${DIM}↑↓${R} navigate  ${DIM}($($sel+1)/$($items.Count))${R}  ${DIM}esc${R} quit     " -NoNewline
-    } else {
-        Write-Host "  ${DIM}↑↓${R} navigate  ${DIM}enter${R} select  ${DIM}esc${R} quit     " -NoNewline
-    }
-}
-
-function FilterList {
-    param($items, $query)
-    if ($query -eq "") { return $items }
-    $q = $query.ToLower()
-    return @($items | Where-Object { $_.ToLower().Contains($q) })
-}
-
-# Setup - ANSI clear + cursor home
-Write-Host "$([char]27)[2J$([char]27)[H${HID}" -NoNewline
-
-# 50ms delay to let terminal settle after repositioning
-Start-Sleep -Milliseconds 50
-
-Write-Host ""
-Write-Host "  ${CYN}` + label + `${R} ${DIM}· select project${R}"
-Write-Host ""
-
-$startY = 3
-$termH = $Host.UI.RawUI.WindowSize.Height
-$maxShow = [Math]::Max(1, $termH - $startY - 4)
-$filtered = $all
-Draw $filtered $sel $filter $startY $viewOffset
-
-# Main loop with try/finally for cursor restore
-$readErr = 0
-try {
-while ($true) {
-    try {
-        $key = $Host.UI.RawUI.ReadKey('NoEcho,IncludeKeyDown')
-        $readErr = 0
-    } catch {
-        $readErr++
-        if ($readErr -ge 20) {
-            Write-Host "${SHW}" -NoNewline
-            Write-Host ""
-            Write-Host "  ${RED}Input error${R}"
-            exit 1
-        }
-        Start-Sleep -Milliseconds 50
-        continue
-    }
-    $vk = $key.VirtualKeyCode
-    $ch = $key.Character
-
-    # Escape - quit
-    if ($vk -eq 27) {
-        Write-Host "${SHW}" -NoNewline
-        Clear-Host
-        exit
-    }
-
-    # Enter - select
-    if ($vk -eq 13) {
-        if ($filtered.Count -gt 0) {
-            $chosen = $filtered[$sel]
-            Write-Host "${SHW}" -NoNewline
-            Clear-Host
-            Write-Host ""
-            Write-Host "  ${GRN}>${R} ${WHT}$chosen${R}"
-            Write-Host ""
-
-            # Account picker phase
-            if ($profileNames.Count -gt 1) {
-                Write-Host "  ${CYN}` + label + `${R} ${DIM}· select account${R}"
-                Write-Host "  ${DIM}─────────────────────────────────${R}"
-                for ($pi = 0; $pi -lt $profileNames.Count; $pi++) {
-                    $num = $pi + 1
-                    Write-Host "  ${WHT}${num}${R}  ${DIM}$($profileNames[$pi])${R}"
-                }
-                Write-Host ""
-                Write-Host "  ${CYN}>${R} " -NoNewline
-
-                $picked = $false
-                while (-not $picked) {
-                    try {
-                        $aKey = $Host.UI.RawUI.ReadKey('NoEcho,IncludeKeyDown')
-                    } catch { continue }
-                    $aVk = $aKey.VirtualKeyCode
-                    if ($aVk -eq 27) {
-                        Write-Host "${SHW}" -NoNewline
-                        Clear-Host
-                        exit
-                    }
-                    $aNum = $aVk - 48
-                    if ($aNum -ge 1 -and $aNum -le $profileNames.Count) {
-                        $idx = $aNum - 1
-                        $env:CLAUDE_CONFIG_DIR = $profileDirs[$idx]
-                        if ($profileKeys[$idx] -ne '') {
-                            $env:ANTHROPIC_API_KEY = $profileKeys[$idx]
-                        }
-                        Write-Host "$($profileNames[$idx])"
-                        Write-Host ""
-                        $picked = $true
-                    }
-                }
-            } elseif ($profileNames.Count -eq 1) {
-                $env:CLAUDE_CONFIG_DIR = $profileDirs[0]
-                if ($profileKeys[0] -ne '') {
-                    $env:ANTHROPIC_API_KEY = $profileKeys[0]
-                }
-            }
-
-            Set-Location (Join-Path $d $chosen)
-            ` + command + `
-            break
-        }
-    }
-
-    # Up arrow
-    if ($vk -eq 38) {
-        if ($sel -gt 0) {
-            $sel--
-            if ($sel -lt $viewOffset) { $viewOffset = $sel }
-        }
-        Draw $filtered $sel $filter $startY $viewOffset
-        continue
-    }
-
-    # Down arrow
-    if ($vk -eq 40) {
-        if ($sel -lt ($filtered.Count - 1)) {
-            $sel++
-            if ($sel -ge ($viewOffset + $maxShow)) { $viewOffset = $sel - $maxShow + 1 }
-        }
-        Draw $filtered $sel $filter $startY $viewOffset
-        continue
-    }
-
-    # Backspace
-    if ($vk -eq 8) {
-        if ($filter.Length -gt 0) {
-            $filter = $filter.Substring(0, $filter.Length - 1)
-            $filtered = @(FilterList $all $filter)
-            $sel = 0
-            $viewOffset = 0
-            Draw $filtered $sel $filter $startY $viewOffset
-        }
-        continue
-    }
-
-    # Printable character - add to filter
-    $code = [int]$ch
-    if ($code -gt 32 -and $code -le 126) {
-        $filter += $ch
-        $filtered = @(FilterList $all $filter)
-        $sel = 0
-        $viewOffset = 0
-        Draw $filtered $sel $filter $startY $viewOffset
-    }
-}
-} finally {
-    Write-Host "${SHW}" -NoNewline
-}
-`
-}
-
-// LaunchTab opens a new tab in the current Windows Terminal window
-func LaunchTab(workingDir, command, label string, profiles []config.Profile) error {
-	script := buildPickerScript(workingDir, command, label, profiles)
-	encoded := encodePS(script)
-	args := []string{
-		"-w", "0",
-		"new-tab",
-		"-d", workingDir,
-		"powershell", "-NoExit", "-EncodedCommand", encoded,
+// Reposition finds already-open windows by title and moves them to the
+// position in their config. Nothing is launched.
+func Reposition(ctx context.Context, p Placer, configs []LaunchConfig) []LaunchResult {
+	results := make([]LaunchResult, len(configs))
+	specs := make([]Spec, len(configs))
+	for i, cfg := range configs {
+		specs[i] = SpecFor(cfg)
+		results[i].Title = cfg.Title
 	}
-	cmd := exec.Command("wt", args...)
-	return cmd.Start()
-}
-
-// LaunchTerminal launches a single terminal (kept for backward compat)
-func LaunchTerminal(cfg LaunchConfig) error {
-	results := LaunchAll([]LaunchConfig{cfg})
-	return results[0].Err
+	positionAll(ctx, p, specs, results)
+	return results
 }
 
-func findWindowByTitle(title string) (uintptr, error) {
-	var foundHwnd uintptr
-
-	// Poll at 50ms intervals instead of 200ms — find window as soon as it appears
-	for attempts := 0; attempts < 40; attempts++ {
-		callback := syscall.NewCallback(func(hwnd uintptr, lParam uintptr) uintptr {
-			var windowTitle [256]uint16
-			procGetWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&windowTitle[0])), 256)
-
-			text := syscall.UTF16ToString(windowTitle[:])
-			if text == title || containsSubstring(text, title) {
-				foundHwnd = hwnd
-				return 0
-			}
-			return 1
-		})
-
-		procEnumWindows.Call(callback, 0)
-
-		if foundHwnd != 0 {
-			return foundHwnd, nil
+// positionAll places every window whose result has no error yet
+func positionAll(ctx context.Context, p Placer, specs []Spec, results []LaunchResult) {
+	var pending []Spec
+	var idx []int
+	for i, spec := range specs {
+		if results[i].Err == nil {
+			pending = append(pending, spec)
+			idx = append(idx, i)
 		}
-
-		time.Sleep(50 * time.Millisecond)
 	}
-
-	return 0, fmt.Errorf("window with title '%s' not found", title)
-}
-
-func containsSubstring(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) &&
-		(s[:len(substr)] == substr || s[len(s)-len(substr):] == substr ||
-			findSubstring(s, substr)))
-}
-
-func findSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+	if len(pending) == 0 {
+		return
 	}
-	return false
-}
-
-func setWindowPosition(hwnd uintptr, x, y, width, height int) error {
-	ret, _, err := procSetWindowPos.Call(
-		hwnd,
-		HWND_TOP,
-		uintptr(x),
-		uintptr(y),
-		uintptr(width),
-		uintptr(height),
-		SWP_NOZORDER|SWP_SHOWWINDOW,
-	)
-
-	if ret == 0 {
-		return fmt.Errorf("SetWindowPos failed: %v", err)
+	for j, err := range p.Place(ctx, pending) {
+		results[idx[j]].Err = err
 	}
-
-	return nil
 }
 
-// GetCurrentConsoleWindow returns the HWND of the current console window
-func GetCurrentConsoleWindow() uintptr {
-	hwnd, _, _ := procGetConsoleWindow.Call()
-	return hwnd
+// LaunchTab opens a new tab in the current terminal window
+func LaunchTab(l Launcher, workingDir, command, label string, profiles []config.Profile) error {
+	return l.NewTab(SpecFor(LaunchConfig{
+		WorkingDir: workingDir,
+		Command:    command,
+		Label:      label,
+		Profiles:   profiles,
+	}))
 }
 
-// RunPickerInCurrent runs the picker script in the current terminal (blocking).
-// Bug fix: removed -NoExit so the process exits cleanly after picker selection.
-func RunPickerInCurrent(workingDir, command, label string, profiles []config.Profile) error {
-	script := buildPickerScript(workingDir, command, label, profiles)
-	encoded := encodePS(script)
-
-	cmd := exec.Command("powershell", "-EncodedCommand", encoded)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// LaunchTerminal launches a single terminal (kept for backward compat)
+func LaunchTerminal(ctx context.Context, l Launcher, cfg LaunchConfig) error {
+	results := LaunchAll(ctx, l, []LaunchConfig{cfg})
+	return results[0].Err
+}
 
-	return cmd.Run()
+// RunPickerInCurrent runs the picker for s in the current terminal and then
+// becomes the chosen command. Marked projects open through Opener.
+func RunPickerInCurrent(s picker.Session) error {
+	if !s.NoLaunch {
+		s.Open = Opener(s)
+	}
+	return picker.Pick(picker.Stdio(), s)
 }
 
 // LaunchAllWithCurrentResult holds the results and a picker function
@@ -567,7 +270,7 @@ type LaunchAllWithCurrentResult struct {
 
 // LaunchAllWithCurrent launches terminals where index 0 uses the current terminal
 // and indexes 1+ spawn new windows. Each config carries its own Command and Label.
-func LaunchAllWithCurrent(configs []LaunchConfig) LaunchAllWithCurrentResult {
+func LaunchAllWithCurrent(ctx context.Context, l Launcher, configs []LaunchConfig) LaunchAllWithCurrentResult {
 	if len(configs) == 0 {
 		return LaunchAllWithCurrentResult{
 			Results:   nil,
@@ -576,72 +279,65 @@ func LaunchAllWithCurrent(configs []LaunchConfig) LaunchAllWithCurrentResult {
 	}
 
 	results := make([]LaunchResult, len(configs))
+	specs := make([]Spec, len(configs))
 	for i, cfg := range configs {
+		specs[i] = SpecFor(cfg)
 		results[i].Title = cfg.Title
 	}
 
-	// Get current console window handle for positioning
-	currentHwnd := GetCurrentConsoleWindow()
-
-	// Launch additional windows (configs[1:]) via wt — each with its own command/label
-	if len(configs) > 1 {
-		for i := 1; i < len(configs); i++ {
-			cfg := configs[i]
-			script := buildPickerScript(cfg.WorkingDir, cfg.Command, cfg.Label, cfg.Profiles)
-			encoded := encodePS(script)
-			args := []string{
-				"--title", cfg.Title,
-				"-d", cfg.WorkingDir,
-				"powershell", "-NoExit", "-EncodedCommand", encoded,
-			}
-			cmd := exec.Command("wt", args...)
-			if err := cmd.Start(); err != nil {
-				results[i].Err = fmt.Errorf("failed to launch: %w", err)
-			}
+	// Launch additional windows (configs[1:]) — each with its own command/label
+	for i := 1; i < len(configs); i++ {
+		if err := l.NewWindow(specs[i]); err != nil {
+			results[i].Err = err
 		}
-
-		// Wait for windows to appear
-		time.Sleep(300 * time.Millisecond)
 	}
 
-	// Position all windows in parallel
-	var wg sync.WaitGroup
-
-	// Position current terminal (index 0)
-	if currentHwnd != 0 {
+	if p, ok := l.(Placer); ok {
+		// Position the current terminal (index 0) while waiting for the spawned windows
+		var wg sync.WaitGroup
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			cfg := configs[0]
-			if err := setWindowPosition(currentHwnd, cfg.X, cfg.Y, cfg.Width, cfg.Height); err != nil {
-				results[0].Err = fmt.Errorf("failed to position current window: %w", err)
-			}
+			results[0].Err = p.PlaceCurrent(specs[0])
 		}()
+		positionAll(ctx, p, specs[1:], results[1:])
+		wg.Wait()
 	}
 
-	// Position spawned windows (configs[1:])
-	for i := 1; i < len(configs); i++ {
-		if results[i].Err != nil {
-			continue
+	// Return results and a picker function — uses first config's command/label
+	picker := func() error {
+		return RunPickerInCurrent(picker.Session{
+			Dir:      configs[0].WorkingDir,
+			Command:  configs[0].Command,
+			Label:    configs[0].Label,
+			Title:    configs[0].PickedTitle,
+			Profiles: configs[0].Profiles,
+			CdFile:   configs[0].CdFile,
+			Loop:     configs[0].Loop,
+		})
+	}
+
+	return LaunchAllWithCurrentResult{
+		Results:   results,
+		RunPicker: picker,
+	}
+}
+
+// LaunchGroups opens each monitor group as one window through gl. When the
+// launcher has a session to join, the returned picker attaches to it instead
+// of running a picker in the current terminal.
+func LaunchGroups(gl GroupLauncher, groups []Group) LaunchAllWithCurrentResult {
+	var results []LaunchResult
+	for _, g := range groups {
+		err := gl.LaunchGroup(g)
+		for _, cfg := range g.Configs {
+			results = append(results, LaunchResult{Title: cfg.Title, Err: err})
 		}
-		wg.Add(1)
-		go func(idx int, cfg LaunchConfig) {
-			defer wg.Done()
-			hwnd, err := findWindowByTitle(cfg.Title)
-			if err != nil {
-				results[idx].Err = fmt.Errorf("failed to find window: %w", err)
-				return
-			}
-			if err := setWindowPosition(hwnd, cfg.X, cfg.Y, cfg.Width, cfg.Height); err != nil {
-				results[idx].Err = fmt.Errorf("failed to position: %w", err)
-			}
-		}(i, configs[i])
 	}
-	wg.Wait()
 
-	// Return results and a picker function — uses first config's command/label
-	picker := func() error {
-		return RunPickerInCurrent(configs[0].WorkingDir, configs[0].Command, configs[0].Label, configs[0].Profiles)
+	picker := func() error { return nil }
+	if a, ok := gl.(Attacher); ok {
+		picker = a.Attach
 	}
 
 	return LaunchAllWithCurrentResult{
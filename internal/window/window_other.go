@@ -0,0 +1,28 @@
+//go:build !windows
+
+package window
+
+import "fmt"
+
+var errNoWindowManager = fmt.Errorf("window positioning is only supported on Windows")
+
+// win32Enumerator has no windows to list off Windows
+type win32Enumerator struct{}
+
+func (win32Enumerator) Windows() ([]WindowInfo, error) {
+	return nil, errNoWindowManager
+}
+
+func setWindowPosition(hwnd uintptr, x, y, width, height int) error {
+	return errNoWindowManager
+}
+
+// GetCurrentConsoleWindow returns the HWND of the current console window
+func GetCurrentConsoleWindow() uintptr {
+	return 0
+}
+
+// currentWindowCenter returns the middle of the current console window
+func currentWindowCenter() (x, y int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,94 @@
+package window
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	user32             = syscall.NewLazyDLL("user32.dll")
+	procFindWindowW    = user32.NewProc("FindWindowW")
+	procSetWindowPos   = user32.NewProc("SetWindowPos")
+	procEnumWindows    = user32.NewProc("EnumWindows")
+	procGetWindowTextW = user32.NewProc("GetWindowTextW")
+	procGetWindowRect  = user32.NewProc("GetWindowRect")
+
+	kernel32             = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleWindow = kernel32.NewProc("GetConsoleWindow")
+)
+
+const (
+	SWP_NOZORDER   = 0x0004
+	SWP_SHOWWINDOW = 0x0040
+	HWND_TOP       = 0
+)
+
+// win32Enumerator lists top-level windows with EnumWindows
+type win32Enumerator struct{}
+
+var (
+	enumMu      sync.Mutex
+	enumWindows []WindowInfo
+	// One callback for the life of the process: Windows caps how many
+	// callbacks syscall.NewCallback can create
+	enumCallback = syscall.NewCallback(func(hwnd uintptr, lParam uintptr) uintptr {
+		var windowTitle [256]uint16
+		procGetWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&windowTitle[0])), 256)
+
+		if text := syscall.UTF16ToString(windowTitle[:]); text != "" {
+			enumWindows = append(enumWindows, WindowInfo{Handle: hwnd, Title: text})
+		}
+		return 1
+	})
+)
+
+func (win32Enumerator) Windows() ([]WindowInfo, error) {
+	enumMu.Lock()
+	defer enumMu.Unlock()
+
+	enumWindows = nil
+	ret, _, err := procEnumWindows.Call(enumCallback, 0)
+	if ret == 0 {
+		return nil, fmt.Errorf("EnumWindows failed: %v", err)
+	}
+	return enumWindows, nil
+}
+
+func setWindowPosition(hwnd uintptr, x, y, width, height int) error {
+	ret, _, err := procSetWindowPos.Call(
+		hwnd,
+		HWND_TOP,
+		uintptr(x),
+		uintptr(y),
+		uintptr(width),
+		uintptr(height),
+		SWP_NOZORDER|SWP_SHOWWINDOW,
+	)
+
+	if ret == 0 {
+		return fmt.Errorf("SetWindowPos failed: %v", err)
+	}
+
+	return nil
+}
+
+// GetCurrentConsoleWindow returns the HWND of the current console window
+func GetCurrentConsoleWindow() uintptr {
+	hwnd, _, _ := procGetConsoleWindow.Call()
+	return hwnd
+}
+
+// currentWindowCenter returns the middle of the current console window
+func currentWindowCenter() (x, y int, ok bool) {
+	hwnd := GetCurrentConsoleWindow()
+	if hwnd == 0 {
+		return 0, 0, false
+	}
+	var r struct{ Left, Top, Right, Bottom int32 }
+	if ret, _, _ := procGetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&r))); ret == 0 {
+		return 0, 0, false
+	}
+	return int(r.Left+r.Right) / 2, int(r.Top+r.Bottom) / 2, true
+}
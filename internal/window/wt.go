@@ -0,0 +1,168 @@
+package window
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// WindowsTerminal launches terminals through wt.exe and positions them
+// with the Win32 API once they appear.
+type WindowsTerminal struct {
+	Finder Finder // locates launched windows; a nil Enum lists Win32 windows
+}
+
+func (w *WindowsTerminal) Name() string { return "wt" }
+
+func (w *WindowsTerminal) NewWindow(spec Spec) error {
+	return w.run(spec, newWindowArgs(spec))
+}
+
+// DescribeWindow returns the wt command line NewWindow runs for spec
+func (w *WindowsTerminal) DescribeWindow(spec Spec) []string {
+	return append([]string{"wt"}, newWindowArgs(spec)...)
+}
+
+func newWindowArgs(spec Spec) []string {
+	args := []string{"--title", spec.Title, "-d", spec.Dir}
+	return append(args, spec.Argv...)
+}
+
+func (w *WindowsTerminal) NewTab(spec Spec) error {
+	args := []string{"-w", "0", "new-tab"}
+	if spec.Title != "" {
+		args = append(args, "--title", spec.Title)
+	}
+	args = append(args, "-d", spec.Dir)
+	return w.run(spec, append(args, spec.Argv...))
+}
+
+func (w *WindowsTerminal) SplitPane(spec Spec) error {
+	args := []string{"-w", "0", "split-pane"}
+	if spec.Title != "" {
+		args = append(args, "--title", spec.Title)
+	}
+	args = append(args, "-d", spec.Dir)
+	return w.run(spec, append(args, spec.Argv...))
+}
+
+func (w *WindowsTerminal) Place(ctx context.Context, specs []Spec) []error {
+	titles := make([]string, len(specs))
+	for i, spec := range specs {
+		titles[i] = spec.Title
+	}
+
+	f := w.Finder
+	if f.Enum == nil {
+		f.Enum = win32Enumerator{}
+	}
+	found, _ := f.FindAll(ctx, titles)
+
+	errs := make([]error, len(specs))
+	for i, spec := range specs {
+		hwnd, ok := found[spec.Title]
+		if !ok {
+			errs[i] = fmt.Errorf("failed to find window '%s'", spec.Title)
+			continue
+		}
+		if err := setWindowPosition(hwnd, spec.X, spec.Y, spec.Width, spec.Height); err != nil {
+			errs[i] = fmt.Errorf("failed to position: %w", err)
+		}
+	}
+	return errs
+}
+
+func (w *WindowsTerminal) PlaceCurrent(spec Spec) error {
+	hwnd := GetCurrentConsoleWindow()
+	if hwnd == 0 {
+		return nil
+	}
+	if err := setWindowPosition(hwnd, spec.X, spec.Y, spec.Width, spec.Height); err != nil {
+		return fmt.Errorf("failed to position current window: %w", err)
+	}
+	return nil
+}
+
+// run starts wt without waiting for it; wt hands off to the terminal and exits
+func (w *WindowsTerminal) run(spec Spec, args []string) error {
+	cmd := exec.Command("wt", args...)
+	if len(spec.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range spec.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to launch: %w", err)
+	}
+	return nil
+}
+
+// WindowsTerminalPanes opens each monitor as one maximized Windows Terminal
+// window split into panes. The whole window is built from a single wt
+// command line and placed with --pos, so no window has to be found afterwards.
+type WindowsTerminalPanes struct {
+	wt WindowsTerminal
+}
+
+func (w *WindowsTerminalPanes) Name() string { return "wt-panes" }
+
+func (w *WindowsTerminalPanes) NewWindow(spec Spec) error { return w.wt.NewWindow(spec) }
+func (w *WindowsTerminalPanes) NewTab(spec Spec) error    { return w.wt.NewTab(spec) }
+func (w *WindowsTerminalPanes) SplitPane(spec Spec) error { return w.wt.SplitPane(spec) }
+
+// LaunchGroup opens the group's window with all of its panes in one wt call
+func (w *WindowsTerminalPanes) LaunchGroup(g Group) error {
+	if len(g.Configs) == 0 {
+		return nil
+	}
+	return w.wt.run(Spec{}, wtPaneArgs(g))
+}
+
+// DescribeGroups returns the wt command line LaunchGroup runs for each group
+func (w *WindowsTerminalPanes) DescribeGroups(groups []Group) [][]string {
+	var cmds [][]string
+	for _, g := range groups {
+		if len(g.Configs) > 0 {
+			cmds = append(cmds, append([]string{"wt"}, wtPaneArgs(g)...))
+		}
+	}
+	return cmds
+}
+
+// wtPaneArgs builds the wt command line for a monitor group: a new window
+// maximized on the monitor, a tab for the first config, then one split-pane
+// per remaining config following SplitPlan. wt always splits the focused
+// pane, so focus is moved back to the parent whenever it is not the pane
+// just created.
+func wtPaneArgs(g Group) []string {
+	first := SpecFor(g.Configs[0])
+	args := []string{
+		"--pos", fmt.Sprintf("%d,%d", first.X, first.Y),
+		"--maximized",
+		"-w", "new",
+		"new-tab", "--title", first.Title, "-d", first.Dir,
+	}
+	args = append(args, first.Argv...)
+
+	focused := 0
+	for i, s := range SplitPlan(len(g.Configs), g.Layout) {
+		if s.Parent != focused {
+			args = append(args, ";", "focus-pane", "-t", fmt.Sprint(s.Parent))
+		}
+
+		spec := SpecFor(g.Configs[s.Cell])
+		dir := "-V" // new pane to the right
+		if s.Direction == SplitDown {
+			dir = "-H"
+		}
+		args = append(args, ";", "split-pane", dir,
+			"--size", strconv.FormatFloat(s.Size, 'f', 4, 64),
+			"--title", spec.Title, "-d", spec.Dir)
+		args = append(args, spec.Argv...)
+		focused = i + 1
+	}
+	return args
+}
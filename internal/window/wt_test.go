@@ -0,0 +1,31 @@
+package window
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWtPaneArgs(t *testing.T) {
+	g := Group{Monitor: 1, Layout: "grid"}
+	for i, title := range []string{"cc-2-1", "cc-2-2", "cc-2-3", "cx-2-4"} {
+		g.Configs = append(g.Configs, LaunchConfig{Title: title, WorkingDir: `C:\dev`, X: 1536 + i, Y: 0})
+	}
+
+	// Drop the picker command lines to keep the expectation readable
+	got := strings.Join(wtPaneArgs(g), " ")
+	for _, cfg := range g.Configs {
+		got = strings.Replace(got, " "+strings.Join(pickerArgv(cfg), " "), "", 1)
+	}
+
+	want := strings.Join([]string{
+		"--pos 1536,0 --maximized -w new new-tab --title cc-2-1 -d C:\\dev",
+		"; split-pane -V --size 0.5000 --title cc-2-2 -d C:\\dev",
+		"; focus-pane -t 0",
+		"; split-pane -H --size 0.5000 --title cc-2-3 -d C:\\dev",
+		"; focus-pane -t 1",
+		"; split-pane -H --size 0.5000 --title cx-2-4 -d C:\\dev",
+	}, " ")
+	if got != want {
+		t.Errorf("wtPaneArgs:\n got %s\nwant %s", got, want)
+	}
+}